@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/juanfont/headscale/gen/go/headscale/v1"
+	"github.com/juanfont/headscale/hscontrol/util"
+	"github.com/prometheus/common/model"
+	"github.com/pterm/pterm"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	DefaultAccessGrantExpiry = "1h"
+)
+
+func init() {
+	rootCmd.AddCommand(accessGrantsCmd)
+	accessGrantsCmd.AddCommand(listAccessGrantsCmd)
+
+	createAccessGrantCmd.Flags().StringSlice("src", []string{}, "Sources, as in the acls of the policy (repeatable)")
+	createAccessGrantCmd.Flags().StringSlice("dst", []string{}, "Destinations without ports, as in the acls of the policy (repeatable)")
+	createAccessGrantCmd.Flags().String("ports", "*", "Ports of the destinations, e.g. 22 or 80,443")
+	createAccessGrantCmd.Flags().String("proto", "", "Protocol, as in the acls of the policy")
+	createAccessGrantCmd.Flags().StringP("expiration", "e", DefaultAccessGrantExpiry, "Human-readable expiration of the grant (e.g. 30m, 24h)")
+	createAccessGrantCmd.Flags().StringP("reason", "r", "", "Reason for the access")
+	for _, flag := range []string{"src", "dst"} {
+		if err := createAccessGrantCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatal().Err(err).Msg("")
+		}
+	}
+	accessGrantsCmd.AddCommand(createAccessGrantCmd)
+
+	revokeAccessGrantCmd.Flags().Uint64P("id", "i", 0, "Access grant ID")
+	if err := revokeAccessGrantCmd.MarkFlagRequired("id"); err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	accessGrantsCmd.AddCommand(revokeAccessGrantCmd)
+}
+
+var accessGrantsCmd = &cobra.Command{
+	Use:     "accessgrants",
+	Short:   "Manage temporary access grants",
+	Aliases: []string{"accessgrant"},
+}
+
+var listAccessGrantsCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List the access grants that have not expired",
+	Aliases: []string{"ls", "show"},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		response, err := client.ListAccessGrants(ctx, &v1.ListAccessGrantsRequest{})
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot list access grants: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		if output != "" {
+			SuccessOutput(response.GetAccessGrants(), "", output)
+		}
+
+		tableData := pterm.TableData{
+			{"ID", "Sources", "Destinations", "Ports", "Protocol", "Expiration", "Author", "Reason"},
+		}
+		for _, grant := range response.GetAccessGrants() {
+			tableData = append(tableData, []string{
+				strconv.FormatUint(grant.GetId(), util.Base10),
+				strings.Join(grant.GetSources(), "\n"),
+				strings.Join(grant.GetDestinations(), "\n"),
+				grant.GetPorts(),
+				grant.GetProtocol(),
+				ColourTime(grant.GetExpiration().AsTime()),
+				grant.GetAuthor(),
+				grant.GetReason(),
+			})
+		}
+
+		err = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Failed to render pterm table: %s", err),
+				output,
+			)
+		}
+	},
+}
+
+var createAccessGrantCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Grant temporary access",
+	Long: `
+Adds a temporary rule to the ACL Policy, allowing the sources to reach the destinations
+on the ports until the grant expires or is revoked. The nodes gaining access are updated
+immediately, and again when the grant is removed.`,
+	Aliases: []string{"c", "new"},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		srcs, _ := cmd.Flags().GetStringSlice("src")
+		dsts, _ := cmd.Flags().GetStringSlice("dst")
+		ports, _ := cmd.Flags().GetString("ports")
+		proto, _ := cmd.Flags().GetString("proto")
+		reason, _ := cmd.Flags().GetString("reason")
+		durationStr, _ := cmd.Flags().GetString("expiration")
+
+		duration, err := model.ParseDuration(durationStr)
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Could not parse duration: %s", err),
+				output,
+			)
+		}
+
+		request := &v1.CreateAccessGrantRequest{
+			Sources:      srcs,
+			Destinations: dsts,
+			Ports:        ports,
+			Protocol:     proto,
+			Reason:       reason,
+			Expiration:   timestamppb.New(time.Now().UTC().Add(time.Duration(duration))),
+		}
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		response, err := client.CreateAccessGrant(ctx, request)
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot create access grant: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		SuccessOutput(
+			response.GetAccessGrant(),
+			fmt.Sprintf(
+				"Access grant %d created, expires at %s",
+				response.GetAccessGrant().GetId(),
+				response.GetAccessGrant().GetExpiration().AsTime().Format(HeadscaleDateTimeFormat),
+			),
+			output,
+		)
+	},
+}
+
+var revokeAccessGrantCmd = &cobra.Command{
+	Use:     "revoke",
+	Short:   "Revoke an access grant before it expires",
+	Aliases: []string{"delete", "rm"},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		id, _ := cmd.Flags().GetUint64("id")
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		response, err := client.RevokeAccessGrant(ctx, &v1.RevokeAccessGrantRequest{Id: id})
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot revoke access grant: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		SuccessOutput(response, "Access grant revoked", output)
+	},
+}
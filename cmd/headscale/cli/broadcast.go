@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+
+	v1 "github.com/juanfont/headscale/gen/go/headscale/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+)
+
+func init() {
+	rootCmd.AddCommand(broadcastCmd)
+	broadcastCmd.Flags().Bool("clear", false, "Clear the last message")
+}
+
+var broadcastCmd = &cobra.Command{
+	Use:   "broadcast MESSAGE",
+	Short: "Show a message in the health UI of every client",
+	Long: `
+Shows a message in the health UI of every client, e.g. in "tailscale status",
+replacing the last one, until it is cleared with --clear.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if clearMessage, _ := cmd.Flags().GetBool("clear"); !clearMessage && len(args) < 1 {
+			return errMissingParameter
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		clearMessage, _ := cmd.Flags().GetBool("clear")
+
+		request := &v1.BroadcastMessageRequest{}
+		if !clearMessage {
+			request.Message = args[0]
+		}
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		response, err := client.BroadcastMessage(ctx, request)
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot broadcast message: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		if clearMessage {
+			SuccessOutput(response, "Message cleared", output)
+		}
+
+		SuccessOutput(response, "Message broadcast", output)
+	},
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	v1 "github.com/juanfont/headscale/gen/go/headscale/v1"
+	"github.com/juanfont/headscale/hscontrol/util"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+)
+
+func init() {
+	rootCmd.AddCommand(clientVersionCmd)
+	clientVersionCmd.AddCommand(setClientVersionCmd)
+	clientVersionCmd.AddCommand(unsetClientVersionCmd)
+	clientVersionCmd.AddCommand(outdatedClientsCmd)
+}
+
+var clientVersionCmd = &cobra.Command{
+	Use:   "clientversion",
+	Short: "Manage the latest client version advertised to the nodes",
+}
+
+var setClientVersionCmd = &cobra.Command{
+	Use:   "set VERSION",
+	Short: "Set the latest stable Tailscale version",
+	Long: `
+Sets the latest stable Tailscale version, e.g. 1.76.1, taking precedence over
+client_version.path and client_version.url of the configuration. Nodes running
+an older version are told that an update is available.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errMissingParameter
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		setClientVersion(cmd, args[0])
+	},
+}
+
+var unsetClientVersionCmd = &cobra.Command{
+	Use:   "unset",
+	Short: "Unset the latest stable Tailscale version",
+	Long: `
+Unsets the version set with "headscale clientversion set", falling back to
+client_version.path or client_version.url of the configuration.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		setClientVersion(cmd, "")
+	},
+}
+
+func setClientVersion(cmd *cobra.Command, version string) {
+	output, _ := cmd.Flags().GetString("output")
+
+	ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+	defer cancel()
+	defer conn.Close()
+
+	response, err := client.SetClientVersion(ctx, &v1.SetClientVersionRequest{Version: version})
+	if err != nil {
+		ErrorOutput(
+			err,
+			fmt.Sprintf("Cannot set client version: %s", status.Convert(err).Message()),
+			output,
+		)
+	}
+
+	if response.GetLatestVersion() == "" {
+		SuccessOutput(response, "Client version unset, the latest version is not known", output)
+	}
+
+	SuccessOutput(
+		response,
+		fmt.Sprintf("Latest client version is now %s", response.GetLatestVersion()),
+		output,
+	)
+}
+
+var outdatedClientsCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List the nodes running an older version than the latest",
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		response, err := client.OutdatedClients(ctx, &v1.OutdatedClientsRequest{})
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot list outdated clients: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		if output != "" {
+			SuccessOutput(response, "", output)
+		}
+
+		tableData := pterm.TableData{
+			{"ID", "Hostname", "Name", "User", "Version", "Connected"},
+		}
+		for _, outdated := range response.GetClients() {
+			node := outdated.GetNode()
+
+			var online string
+			if node.GetOnline() {
+				online = pterm.LightGreen("online")
+			} else {
+				online = pterm.LightRed("offline")
+			}
+
+			tableData = append(tableData, []string{
+				strconv.FormatUint(node.GetId(), util.Base10),
+				node.GetName(),
+				node.GetGivenName(),
+				node.GetUser().GetName(),
+				pterm.LightYellow(outdated.GetVersion()),
+				online,
+			})
+		}
+
+		fmt.Printf("Latest client version: %s\n", response.GetLatestVersion())
+
+		err = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Failed to render pterm table: %s", err),
+				output,
+			)
+		}
+	},
+}
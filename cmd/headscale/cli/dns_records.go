@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "github.com/juanfont/headscale/gen/go/headscale/v1"
+	"github.com/juanfont/headscale/hscontrol/util"
+	"github.com/prometheus/common/model"
+	"github.com/pterm/pterm"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func init() {
+	rootCmd.AddCommand(dnsRecordsCmd)
+	dnsRecordsCmd.AddCommand(listDNSRecordsCmd)
+
+	createDNSRecordCmd.Flags().StringP("name", "n", "", "Fully qualified name of the record")
+	createDNSRecordCmd.Flags().StringP("type", "t", "", "A or AAAA, inferred from the value by default")
+	createDNSRecordCmd.Flags().StringP("value", "v", "", "IP address of the record")
+	createDNSRecordCmd.Flags().StringP("expiration", "e", "", "Human-readable expiration of the record (e.g. 30m, 24h), it does not expire by default")
+	for _, flag := range []string{"name", "value"} {
+		if err := createDNSRecordCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatal().Err(err).Msg("")
+		}
+	}
+	dnsRecordsCmd.AddCommand(createDNSRecordCmd)
+
+	deleteDNSRecordCmd.Flags().Uint64P("id", "i", 0, "DNS record ID")
+	if err := deleteDNSRecordCmd.MarkFlagRequired("id"); err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	dnsRecordsCmd.AddCommand(deleteDNSRecordCmd)
+}
+
+var dnsRecordsCmd = &cobra.Command{
+	Use:     "dnsrecords",
+	Short:   "Manage the DNS records sent to the nodes",
+	Aliases: []string{"dnsrecord", "records"},
+}
+
+var listDNSRecordsCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List the DNS records that have not expired",
+	Aliases: []string{"ls", "show"},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		response, err := client.ListDNSRecords(ctx, &v1.ListDNSRecordsRequest{})
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot list DNS records: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		if output != "" {
+			SuccessOutput(response.GetDnsRecords(), "", output)
+		}
+
+		tableData := pterm.TableData{
+			{"ID", "Name", "Type", "Value", "Expiration", "Author"},
+		}
+		for _, record := range response.GetDnsRecords() {
+			expiration := "N/A"
+			if record.GetExpiration() != nil {
+				expiration = ColourTime(record.GetExpiration().AsTime())
+			}
+
+			tableData = append(tableData, []string{
+				strconv.FormatUint(record.GetId(), util.Base10),
+				record.GetName(),
+				record.GetType(),
+				record.GetValue(),
+				expiration,
+				record.GetAuthor(),
+			})
+		}
+
+		err = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Failed to render pterm table: %s", err),
+				output,
+			)
+		}
+	},
+}
+
+var createDNSRecordCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a DNS record",
+	Long: `
+Adds a DNS record to the DNS config of every node, along with the extra_records
+of the configuration, until it expires or is deleted. Only A and AAAA records
+are supported, Tailscale clients do not resolve other types for now.`,
+	Aliases: []string{"c", "new"},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		name, _ := cmd.Flags().GetString("name")
+		recordType, _ := cmd.Flags().GetString("type")
+		value, _ := cmd.Flags().GetString("value")
+		durationStr, _ := cmd.Flags().GetString("expiration")
+
+		request := &v1.CreateDNSRecordRequest{
+			Name:  name,
+			Type:  recordType,
+			Value: value,
+		}
+
+		if durationStr != "" {
+			duration, err := model.ParseDuration(durationStr)
+			if err != nil {
+				ErrorOutput(
+					err,
+					fmt.Sprintf("Could not parse duration: %s", err),
+					output,
+				)
+			}
+
+			request.Expiration = timestamppb.New(time.Now().UTC().Add(time.Duration(duration)))
+		}
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		response, err := client.CreateDNSRecord(ctx, request)
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot create DNS record: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		SuccessOutput(
+			response.GetDnsRecord(),
+			fmt.Sprintf("DNS record %d created", response.GetDnsRecord().GetId()),
+			output,
+		)
+	},
+}
+
+var deleteDNSRecordCmd = &cobra.Command{
+	Use:     "delete",
+	Short:   "Delete a DNS record",
+	Aliases: []string{"remove", "rm"},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		id, _ := cmd.Flags().GetUint64("id")
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		response, err := client.DeleteDNSRecord(ctx, &v1.DeleteDNSRecordRequest{Id: id})
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot delete DNS record: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		SuccessOutput(response, "DNS record deleted", output)
+	},
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/netip"
@@ -48,6 +49,17 @@ func init() {
 	}
 	nodeCmd.AddCommand(registerNodeCmd)
 
+	approveSSHCheckCmd.Flags().StringP("check", "c", "", "SSH check identifier, as shown in the authentication URL")
+	err = approveSSHCheckCmd.MarkFlagRequired("check")
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+	nodeCmd.AddCommand(approveSSHCheckCmd)
+
+	pingNodeCmd.Flags().StringP("type", "t", "c2n", "Ping type: disco, TSMP or c2n")
+	pingNodeCmd.Flags().Uint64("target", 0, "Node pinged by the node for the disco and TSMP types (ID)")
+	nodeCmd.AddCommand(pingNodeCmd)
+
 	expireNodeCmd.Flags().Uint64P("identifier", "i", 0, "Node identifier (ID)")
 	err = expireNodeCmd.MarkFlagRequired("identifier")
 	if err != nil {
@@ -154,6 +166,117 @@ var registerNodeCmd = &cobra.Command{
 	},
 }
 
+var approveSSHCheckCmd = &cobra.Command{
+	Use:   "approve-ssh",
+	Short: "Approves an SSH connection held by a check action of the policy",
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		checkID, err := cmd.Flags().GetString("check")
+		if err != nil {
+			ErrorOutput(err, fmt.Sprintf("Error getting check from flag: %s", err), output)
+		}
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		request := &v1.ApproveSSHCheckRequest{
+			CheckId: checkID,
+		}
+
+		response, err := client.ApproveSSHCheck(ctx, request)
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf(
+					"Cannot approve SSH check: %s\n",
+					status.Convert(err).Message(),
+				),
+				output,
+			)
+		}
+
+		SuccessOutput(
+			response,
+			fmt.Sprintf(
+				"SSH connection from %s to %s as %s approved",
+				response.GetSrcNode().GetGivenName(),
+				response.GetDstNode().GetGivenName(),
+				response.GetLocalUser(),
+			),
+			output,
+		)
+	},
+}
+
+var pingNodeCmd = &cobra.Command{
+	Use:   "ping ID",
+	Short: "Pings a node from the control server",
+	Long: `
+Sends a ping request to a connected node and reports the latency and path.
+
+A c2n ping checks that the node answers through its connection to headscale.
+A disco or TSMP ping asks the node to ping the --target node, and reports if
+the path between them is direct or relayed through DERP.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errMissingParameter
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		pingType, _ := cmd.Flags().GetString("type")
+		target, _ := cmd.Flags().GetUint64("target")
+
+		nodeID, err := strconv.ParseUint(args[0], util.Base10, 64)
+		if err != nil {
+			ErrorOutput(err, fmt.Sprintf("Invalid node ID: %s", err), output)
+		}
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		request := &v1.PingNodeRequest{
+			NodeId:       nodeID,
+			Type:         pingType,
+			TargetNodeId: target,
+		}
+
+		response, err := client.PingNode(ctx, request)
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot ping node: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		if response.GetError() != "" {
+			ErrorOutput(
+				errors.New(response.GetError()),
+				fmt.Sprintf("Ping failed: %s", response.GetError()),
+				output,
+			)
+		}
+
+		latency := time.Duration(response.GetLatencySeconds() * float64(time.Second))
+		message := fmt.Sprintf("%s pong in %s", response.GetType(), latency.Round(time.Microsecond))
+		switch response.GetPath() {
+		case "direct":
+			message += fmt.Sprintf(" via %s", response.GetEndpoint())
+		case "derp":
+			message += fmt.Sprintf(" via DERP(%s)", response.GetDerpRegion())
+		case "control":
+			message += " via control"
+		}
+
+		SuccessOutput(response, message, output)
+	},
+}
+
 var listNodesCmd = &cobra.Command{
 	Use:     "list",
 	Short:   "List nodes",
@@ -4,23 +4,61 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	v1 "github.com/juanfont/headscale/gen/go/headscale/v1"
+	"github.com/pterm/pterm"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
 )
 
 func init() {
 	rootCmd.AddCommand(policyCmd)
+	getPolicy.Flags().Uint64P("revision", "r", 0, "Revision of the policy to print, defaults to the current one")
 	policyCmd.AddCommand(getPolicy)
 
 	setPolicy.Flags().StringP("file", "f", "", "Path to a policy file in HuJSON format")
+	setPolicy.Flags().StringP("message", "m", "", "Description of the change")
+	setPolicy.Flags().Bool("dry-run", false, "Show how the policy would change the peers and packet filter of every node without applying it")
 	if err := setPolicy.MarkFlagRequired("file"); err != nil {
 		log.Fatal().Err(err).Msg("")
 	}
 	policyCmd.AddCommand(setPolicy)
+
+	policyCmd.AddCommand(policyHistory)
+
+	rollbackPolicy.Flags().Uint64P("revision", "r", 0, "Revision of the policy to roll back to")
+	rollbackPolicy.Flags().StringP("message", "m", "", "Description of the change")
+	if err := rollbackPolicy.MarkFlagRequired("revision"); err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	policyCmd.AddCommand(rollbackPolicy)
+
+	checkPolicy.Flags().String("src", "", "Source node (ID or name), user or IP address")
+	checkPolicy.Flags().String("dst", "", "Destination node (ID or name) or IP address")
+	checkPolicy.Flags().Uint32("port", 0, "Destination port")
+	checkPolicy.Flags().String("proto", "tcp", "Protocol, as used in the policy")
+	for _, flag := range []string{"src", "dst", "port"} {
+		if err := checkPolicy.MarkFlagRequired(flag); err != nil {
+			log.Fatal().Err(err).Msg("")
+		}
+	}
+	policyCmd.AddCommand(checkPolicy)
+
+	lintPolicy.Flags().StringP("file", "f", "", "Path to a policy file in HuJSON format, defaults to the current policy")
+	policyCmd.AddCommand(lintPolicy)
+
+	policyGraph.Flags().String("format", graphFormatDOT, "Format of the graph, dot or json")
+	policyCmd.AddCommand(policyGraph)
 }
 
+const (
+	graphFormatDOT  = "dot"
+	graphFormatJSON = "json"
+)
+
 var policyCmd = &cobra.Command{
 	Use:   "policy",
 	Short: "Manage the Headscale ACL Policy",
@@ -32,10 +70,20 @@ var getPolicy = &cobra.Command{
 	Aliases: []string{"show", "view", "fetch"},
 	Run: func(cmd *cobra.Command, args []string) {
 		output, _ := cmd.Flags().GetString("output")
+		revision, _ := cmd.Flags().GetUint64("revision")
 		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
 		defer cancel()
 		defer conn.Close()
 
+		if revision != 0 {
+			response, err := client.GetPolicyRevision(ctx, &v1.GetPolicyRevisionRequest{Id: revision})
+			if err != nil {
+				ErrorOutput(err, fmt.Sprintf("Failed loading ACL Policy revision: %s", status.Convert(err).Message()), output)
+			}
+
+			SuccessOutput("", response.GetRevision().GetPolicy(), "")
+		}
+
 		request := &v1.GetPolicyRequest{}
 
 		response, err := client.GetPolicy(ctx, request)
@@ -55,11 +103,15 @@ var setPolicy = &cobra.Command{
 	Short: "Updates the ACL Policy",
 	Long: `
 	Updates the existing ACL Policy with the provided policy. The policy must be a valid HuJSON object.
-	This command only works when the acl.policy_mode is set to "db", and the policy will be stored in the database.`,
+	This command only works when the acl.policy_mode is set to "db", and the policy will be stored in the database.
+	With --dry-run, the policy is only validated and the peers gained or lost and the packet filter destinations
+	added or removed for every node are listed.`,
 	Aliases: []string{"put", "update"},
 	Run: func(cmd *cobra.Command, args []string) {
 		output, _ := cmd.Flags().GetString("output")
 		policyPath, _ := cmd.Flags().GetString("file")
+		message, _ := cmd.Flags().GetString("message")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 		f, err := os.Open(policyPath)
 		if err != nil {
@@ -72,16 +124,366 @@ var setPolicy = &cobra.Command{
 			ErrorOutput(err, fmt.Sprintf("Error reading the policy file: %s", err), output)
 		}
 
-		request := &v1.SetPolicyRequest{Policy: string(policyBytes)}
+		request := &v1.SetPolicyRequest{Policy: string(policyBytes), Message: message, DryRun: dryRun}
 
 		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
 		defer cancel()
 		defer conn.Close()
 
-		if _, err := client.SetPolicy(ctx, request); err != nil {
+		response, err := client.SetPolicy(ctx, request)
+		if err != nil {
 			ErrorOutput(err, fmt.Sprintf("Failed to set ACL Policy: %s", err), output)
 		}
 
-		SuccessOutput(nil, "Policy updated.", "")
+		if !dryRun {
+			SuccessOutput(nil, "Policy updated.", "")
+		}
+
+		if output != "" {
+			SuccessOutput(response.GetImpact(), "", output)
+		}
+
+		if len(response.GetImpact()) == 0 {
+			SuccessOutput(nil, "The policy does not change any node.", "")
+		}
+
+		tableData := pterm.TableData{
+			{"ID", "Node", "Peers gained", "Peers lost", "Destinations added", "Destinations removed"},
+		}
+		for _, impact := range response.GetImpact() {
+			tableData = append(tableData, []string{
+				strconv.FormatUint(impact.GetNodeId(), Base10),
+				impact.GetNode(),
+				strings.Join(impact.GetPeersGained(), "\n"),
+				strings.Join(impact.GetPeersLost(), "\n"),
+				strings.Join(impact.GetDestinationsAdded(), "\n"),
+				strings.Join(impact.GetDestinationsRemoved(), "\n"),
+			})
+		}
+
+		err = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Failed to render pterm table: %s", err),
+				output,
+			)
+		}
+	},
+}
+
+var checkPolicy = &cobra.Command{
+	Use:   "check",
+	Short: "Check if a source can reach a destination",
+	Long: `
+	Check if a source can reach a destination with the current ACL Policy.
+	The check is made on the packet filter the destination node receives, and lists
+	the rules of the policy allowing the access. Exits with status 1 if the access
+	is denied.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		src, _ := cmd.Flags().GetString("src")
+		dst, _ := cmd.Flags().GetString("dst")
+		port, _ := cmd.Flags().GetUint32("port")
+		proto, _ := cmd.Flags().GetString("proto")
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		request := &v1.CheckAccessRequest{
+			Source:      src,
+			Destination: dst,
+			Port:        port,
+			Protocol:    proto,
+		}
+
+		response, err := client.CheckAccess(ctx, request)
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot check access: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		if output != "" {
+			accessOutput(response.GetAllowed(), response, "", output)
+		}
+
+		tableData := pterm.TableData{{"Source", "Destination", "Node", "Allowed", "Rules"}}
+		for _, check := range response.GetChecks() {
+			var rules []string
+			for _, match := range check.GetMatches() {
+				rules = append(rules, fmt.Sprintf(
+					"%s[%d]: %s -> %s",
+					match.GetSection(),
+					match.GetIndex(),
+					strings.Join(match.GetSources(), ", "),
+					strings.Join(match.GetDestinations(), ", "),
+				))
+			}
+
+			tableData = append(tableData, []string{
+				check.GetSource(),
+				check.GetDestination(),
+				check.GetNode(),
+				strconv.FormatBool(check.GetAllowed()),
+				strings.Join(rules, "\n"),
+			})
+		}
+
+		err = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Failed to render pterm table: %s", err),
+				output,
+			)
+		}
+
+		if response.GetAllowed() {
+			accessOutput(true, nil, "Access allowed.", "")
+		}
+
+		accessOutput(false, nil, "Access denied.", "")
+	},
+}
+
+// accessOutput prints the result of an access check like SuccessOutput,
+// but exits with status 1 if the access is denied so scripts can rely
+// on the exit status.
+func accessOutput(allowed bool, result interface{}, override string, outputFormat string) {
+	fmt.Println(output(result, override, outputFormat))
+
+	if !allowed {
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+var lintPolicy = &cobra.Command{
+	Use:   "lint",
+	Short: "Report problems of the ACL Policy",
+	Long: `
+	Analyse the current ACL Policy, or the one in --file, against the current users and nodes.
+	Reports groups, hosts and tags that are never used, rules covered by an earlier rule,
+	unknown users in groups and tagOwners, and autoApprovers routes that no node advertises.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		policyPath, _ := cmd.Flags().GetString("file")
+
+		request := &v1.LintPolicyRequest{}
+		if policyPath != "" {
+			policyBytes, err := os.ReadFile(policyPath)
+			if err != nil {
+				ErrorOutput(err, fmt.Sprintf("Error reading the policy file: %s", err), output)
+			}
+
+			request.Policy = string(policyBytes)
+		}
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		response, err := client.LintPolicy(ctx, request)
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot lint policy: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		if output != "" {
+			SuccessOutput(response.GetFindings(), "", output)
+		}
+
+		if len(response.GetFindings()) == 0 {
+			SuccessOutput(nil, "No problems found.", "")
+		}
+
+		tableData := pterm.TableData{{"Check", "Section", "Index", "Name", "Message"}}
+		for _, finding := range response.GetFindings() {
+			index := ""
+			if finding.GetIndex() >= 0 {
+				index = strconv.FormatInt(int64(finding.GetIndex()), Base10)
+			}
+
+			tableData = append(tableData, []string{
+				finding.GetCheck(),
+				finding.GetSection(),
+				index,
+				finding.GetName(),
+				finding.GetMessage(),
+			})
+		}
+
+		err = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Failed to render pterm table: %s", err),
+				output,
+			)
+		}
+	},
+}
+
+var policyGraph = &cobra.Command{
+	Use:   "graph",
+	Short: "Export who can reach whom with the ACL Policy",
+	Long: `
+	Export the access graph of the current ACL Policy, computed from the packet filter and the
+	peers of every node. Nodes and subnet routes are vertices, and edges carry the ports and
+	protocols a node can reach. The DOT format can be rendered with Graphviz, for example
+	"headscale policy graph | dot -Tsvg > access.svg".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		format, _ := cmd.Flags().GetString("format")
+
+		if format != graphFormatDOT && format != graphFormatJSON {
+			ErrorOutput(
+				fmt.Errorf("unknown format %q", format),
+				fmt.Sprintf("Unknown graph format %q, expected dot or json", format),
+				output,
+			)
+		}
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		response, err := client.GetAccessGraph(ctx, &v1.GetAccessGraphRequest{})
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot get access graph: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		if format == graphFormatJSON {
+			SuccessOutput(response, "", graphFormatJSON)
+		}
+
+		SuccessOutput(nil, accessGraphDOT(response), "")
+	},
+}
+
+// accessGraphDOT renders the access graph in the Graphviz DOT language.
+func accessGraphDOT(graph *v1.GetAccessGraphResponse) string {
+	var dot strings.Builder
+
+	dot.WriteString("digraph access {\n")
+	dot.WriteString("\tnode [shape=box];\n")
+
+	for _, vertex := range graph.GetVertices() {
+		label := []string{vertex.GetName()}
+		if vertex.GetUser() != "" {
+			label = append(label, vertex.GetUser())
+		}
+
+		shape := "box"
+		if vertex.GetKind() == "route" {
+			label = append(label, "via "+strings.Join(vertex.GetRouters(), ", "))
+			shape = "ellipse"
+		} else {
+			label = append(label, vertex.GetAddresses()...)
+		}
+
+		fmt.Fprintf(&dot, "\t%q [label=%q, shape=%s];\n", vertex.GetId(), strings.Join(label, "\n"), shape)
+	}
+
+	for _, edge := range graph.GetEdges() {
+		label := strings.Join(edge.GetProtocols(), ", ") + ": " + strings.Join(edge.GetPorts(), ", ")
+		fmt.Fprintf(&dot, "\t%q -> %q [label=%q];\n", edge.GetFrom(), edge.GetTo(), label)
+	}
+
+	dot.WriteString("}")
+
+	return dot.String()
+}
+
+var policyHistory = &cobra.Command{
+	Use:     "history",
+	Short:   "List the revisions of the ACL Policy",
+	Aliases: []string{"revisions", "log"},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		response, err := client.ListPolicyRevisions(ctx, &v1.ListPolicyRevisionsRequest{})
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Cannot list policy revisions: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		if output != "" {
+			SuccessOutput(response.GetRevisions(), "", output)
+		}
+
+		tableData := pterm.TableData{{"Revision", "Created", "Author", "Message"}}
+		for _, revision := range response.GetRevisions() {
+			tableData = append(tableData, []string{
+				strconv.FormatUint(revision.GetId(), Base10),
+				revision.GetCreatedAt().AsTime().Format(HeadscaleDateTimeFormat),
+				revision.GetAuthor(),
+				revision.GetMessage(),
+			})
+		}
+
+		err = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Failed to render pterm table: %s", err),
+				output,
+			)
+		}
+	},
+}
+
+var rollbackPolicy = &cobra.Command{
+	Use:   "rollback",
+	Short: "Roll back the ACL Policy to a previous revision",
+	Long: `
+	Sets the ACL Policy to the one of a previous revision, as a new revision. The policy is
+	validated against the current nodes, like a new policy.
+	This command only works when the acl.policy_mode is set to "db".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		revision, _ := cmd.Flags().GetUint64("revision")
+		message, _ := cmd.Flags().GetString("message")
+
+		ctx, client, conn, cancel := newHeadscaleCLIWithConfig()
+		defer cancel()
+		defer conn.Close()
+
+		response, err := client.RollbackPolicy(ctx, &v1.RollbackPolicyRequest{
+			Id:      revision,
+			Message: message,
+		})
+		if err != nil {
+			ErrorOutput(
+				err,
+				fmt.Sprintf("Failed to roll back ACL Policy: %s", status.Convert(err).Message()),
+				output,
+			)
+		}
+
+		SuccessOutput(
+			response.GetRevision(),
+			fmt.Sprintf("Policy rolled back to revision %d, as revision %d.", revision, response.GetRevision().GetId()),
+			output,
+		)
 	},
 }
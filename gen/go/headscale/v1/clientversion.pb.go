@@ -0,0 +1,421 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: headscale/v1/clientversion.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetClientVersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Latest stable Tailscale version, e.g. "1.76.1". An empty version
+	// falls back to the configured file or URL.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *SetClientVersionRequest) Reset() {
+	*x = SetClientVersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headscale_v1_clientversion_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetClientVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetClientVersionRequest) ProtoMessage() {}
+
+func (x *SetClientVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_headscale_v1_clientversion_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetClientVersionRequest.ProtoReflect.Descriptor instead.
+func (*SetClientVersionRequest) Descriptor() ([]byte, []int) {
+	return file_headscale_v1_clientversion_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetClientVersionRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type SetClientVersionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Latest version advertised to the nodes, empty if unknown.
+	LatestVersion string `protobuf:"bytes,1,opt,name=latest_version,json=latestVersion,proto3" json:"latest_version,omitempty"`
+}
+
+func (x *SetClientVersionResponse) Reset() {
+	*x = SetClientVersionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headscale_v1_clientversion_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetClientVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetClientVersionResponse) ProtoMessage() {}
+
+func (x *SetClientVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_headscale_v1_clientversion_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetClientVersionResponse.ProtoReflect.Descriptor instead.
+func (*SetClientVersionResponse) Descriptor() ([]byte, []int) {
+	return file_headscale_v1_clientversion_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetClientVersionResponse) GetLatestVersion() string {
+	if x != nil {
+		return x.LatestVersion
+	}
+	return ""
+}
+
+type OutdatedClient struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node *Node `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// Tailscale version the node is running.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *OutdatedClient) Reset() {
+	*x = OutdatedClient{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headscale_v1_clientversion_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutdatedClient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutdatedClient) ProtoMessage() {}
+
+func (x *OutdatedClient) ProtoReflect() protoreflect.Message {
+	mi := &file_headscale_v1_clientversion_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutdatedClient.ProtoReflect.Descriptor instead.
+func (*OutdatedClient) Descriptor() ([]byte, []int) {
+	return file_headscale_v1_clientversion_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OutdatedClient) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *OutdatedClient) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type OutdatedClientsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *OutdatedClientsRequest) Reset() {
+	*x = OutdatedClientsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headscale_v1_clientversion_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutdatedClientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutdatedClientsRequest) ProtoMessage() {}
+
+func (x *OutdatedClientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_headscale_v1_clientversion_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutdatedClientsRequest.ProtoReflect.Descriptor instead.
+func (*OutdatedClientsRequest) Descriptor() ([]byte, []int) {
+	return file_headscale_v1_clientversion_proto_rawDescGZIP(), []int{3}
+}
+
+type OutdatedClientsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LatestVersion string            `protobuf:"bytes,1,opt,name=latest_version,json=latestVersion,proto3" json:"latest_version,omitempty"`
+	Clients       []*OutdatedClient `protobuf:"bytes,2,rep,name=clients,proto3" json:"clients,omitempty"`
+}
+
+func (x *OutdatedClientsResponse) Reset() {
+	*x = OutdatedClientsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headscale_v1_clientversion_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutdatedClientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutdatedClientsResponse) ProtoMessage() {}
+
+func (x *OutdatedClientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_headscale_v1_clientversion_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutdatedClientsResponse.ProtoReflect.Descriptor instead.
+func (*OutdatedClientsResponse) Descriptor() ([]byte, []int) {
+	return file_headscale_v1_clientversion_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OutdatedClientsResponse) GetLatestVersion() string {
+	if x != nil {
+		return x.LatestVersion
+	}
+	return ""
+}
+
+func (x *OutdatedClientsResponse) GetClients() []*OutdatedClient {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+var File_headscale_v1_clientversion_proto protoreflect.FileDescriptor
+
+var file_headscale_v1_clientversion_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x68, 0x65, 0x61, 0x64, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0c, 0x68, 0x65, 0x61, 0x64, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2e, 0x76, 0x31,
+	0x1a, 0x17, 0x68, 0x65, 0x61, 0x64, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x6e,
+	0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x33, 0x0a, 0x17, 0x53, 0x65, 0x74,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x41,
+	0x0a, 0x18, 0x53, 0x65, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x22, 0x52, 0x0a, 0x0e, 0x4f, 0x75, 0x74, 0x64, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x18, 0x0a, 0x16, 0x4f, 0x75, 0x74, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x78, 0x0a, 0x17, 0x4f, 0x75, 0x74, 0x64, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x36, 0x0a, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x4f, 0x75, 0x74, 0x64, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x52, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x75, 0x61, 0x6e, 0x66, 0x6f, 0x6e, 0x74,
+	0x2f, 0x68, 0x65, 0x61, 0x64, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67,
+	0x6f, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_headscale_v1_clientversion_proto_rawDescOnce sync.Once
+	file_headscale_v1_clientversion_proto_rawDescData = file_headscale_v1_clientversion_proto_rawDesc
+)
+
+func file_headscale_v1_clientversion_proto_rawDescGZIP() []byte {
+	file_headscale_v1_clientversion_proto_rawDescOnce.Do(func() {
+		file_headscale_v1_clientversion_proto_rawDescData = protoimpl.X.CompressGZIP(file_headscale_v1_clientversion_proto_rawDescData)
+	})
+	return file_headscale_v1_clientversion_proto_rawDescData
+}
+
+var file_headscale_v1_clientversion_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_headscale_v1_clientversion_proto_goTypes = []any{
+	(*SetClientVersionRequest)(nil),  // 0: headscale.v1.SetClientVersionRequest
+	(*SetClientVersionResponse)(nil), // 1: headscale.v1.SetClientVersionResponse
+	(*OutdatedClient)(nil),           // 2: headscale.v1.OutdatedClient
+	(*OutdatedClientsRequest)(nil),   // 3: headscale.v1.OutdatedClientsRequest
+	(*OutdatedClientsResponse)(nil),  // 4: headscale.v1.OutdatedClientsResponse
+	(*Node)(nil),                     // 5: headscale.v1.Node
+}
+var file_headscale_v1_clientversion_proto_depIdxs = []int32{
+	5, // 0: headscale.v1.OutdatedClient.node:type_name -> headscale.v1.Node
+	2, // 1: headscale.v1.OutdatedClientsResponse.clients:type_name -> headscale.v1.OutdatedClient
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_headscale_v1_clientversion_proto_init() }
+func file_headscale_v1_clientversion_proto_init() {
+	if File_headscale_v1_clientversion_proto != nil {
+		return
+	}
+	file_headscale_v1_node_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_headscale_v1_clientversion_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SetClientVersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headscale_v1_clientversion_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*SetClientVersionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headscale_v1_clientversion_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*OutdatedClient); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headscale_v1_clientversion_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*OutdatedClientsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headscale_v1_clientversion_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*OutdatedClientsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_headscale_v1_clientversion_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_headscale_v1_clientversion_proto_goTypes,
+		DependencyIndexes: file_headscale_v1_clientversion_proto_depIdxs,
+		MessageInfos:      file_headscale_v1_clientversion_proto_msgTypes,
+	}.Build()
+	File_headscale_v1_clientversion_proto = out.File
+	file_headscale_v1_clientversion_proto_rawDesc = nil
+	file_headscale_v1_clientversion_proto_goTypes = nil
+	file_headscale_v1_clientversion_proto_depIdxs = nil
+}
@@ -0,0 +1,87 @@
+package hscontrol
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/policy"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/rs/zerolog/log"
+)
+
+// setACLPolicy replaces the policy, adding the access grants that have
+// not expired to it. Every change of the policy goes through it, and the
+// caller must hold policyMu.
+func (h *Headscale) setACLPolicy(pol *policy.ACLPolicy) error {
+	grants, err := h.db.ListAccessGrants()
+	if err != nil {
+		return fmt.Errorf("loading access grants from database: %w", err)
+	}
+
+	h.aclPolicy.Store(pol.WithAccessGrants(grants))
+
+	return nil
+}
+
+// updateAccessGrants adds the access grants that have not expired to the
+// current policy, and sends a full update to the nodes that are a source
+// or a destination of the changed grants.
+func (h *Headscale) updateAccessGrants(origin string, changed []types.AccessGrant) error {
+	h.policyMu.Lock()
+	defer h.policyMu.Unlock()
+
+	if err := h.setACLPolicy(h.ACLPolicy().WithAccessGrants(nil)); err != nil {
+		return err
+	}
+
+	nodes, err := h.db.ListNodes()
+	if err != nil {
+		return fmt.Errorf("loading nodes from database: %w", err)
+	}
+
+	ctx := types.NotifyCtx(context.Background(), origin, "na")
+	for _, node := range h.ACLPolicy().AccessGrantNodes(changed, nodes) {
+		h.nodeNotifier.NotifyByNodeID(ctx, types.StateUpdate{
+			Type: types.StateFullUpdate,
+		}, node.ID)
+	}
+
+	return nil
+}
+
+// expireAccessGrants deletes the access grants after they expire, and
+// removes them from the policy.
+func (h *Headscale) expireAccessGrants(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+
+	for {
+		select {
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		case <-ticker.C:
+			expired, err := h.db.DeleteExpiredAccessGrants(time.Now())
+			if err != nil {
+				log.Error().Err(err).Msg("database error while expiring access grants")
+				continue
+			}
+
+			if len(expired) == 0 {
+				continue
+			}
+
+			for _, grant := range expired {
+				log.Info().
+					Uint64("access_grant", grant.ID).
+					Strs("src", grant.Sources).
+					Strs("dst", grant.Destinations).
+					Msg("Access grant expired")
+			}
+
+			if err := h.updateAccessGrants("access-grant-expired", expired); err != nil {
+				log.Error().Err(err).Msg("failed to remove expired access grants from the policy")
+			}
+		}
+	}
+}
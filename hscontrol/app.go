@@ -2,7 +2,9 @@ package hscontrol
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -15,10 +17,12 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/fsnotify/fsnotify"
 	"github.com/gorilla/mux"
 	grpcMiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpcRuntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -65,6 +69,7 @@ var (
 	errEmptyInitialDERPMap = errors.New(
 		"initial DERPMap is empty, Headscale requires at least one entry",
 	)
+	errInvalidPolicy = errors.New("invalid policy")
 )
 
 const (
@@ -75,6 +80,8 @@ const (
 
 	registerCacheExpiration = time.Minute * 15
 	registerCacheCleanup    = time.Minute * 20
+
+	policyReloadDebounce = time.Second
 )
 
 // Headscale represents the base app of the service.
@@ -88,14 +95,26 @@ type Headscale struct {
 	DERPMap    *tailcfg.DERPMap
 	DERPServer *derpServer.DERPServer
 
-	ACLPolicy *policy.ACLPolicy
+	// policyMu serialises the changes of the policy, aclPolicy can be
+	// read without it.
+	policyMu     sync.Mutex
+	aclPolicy    atomic.Pointer[policy.ACLPolicy]
+	policyLoader *policy.URLLoader
+	policyHash   string
 
 	mapper       *mapper.Mapper
 	nodeNotifier *notifier.Notifier
 
+	clientVersionMu      sync.Mutex
+	fetchedClientVersion string
+
+	dnsRecordsMu sync.Mutex
+
 	registrationCache *zcache.Cache[string, types.Node]
 
 	authProvider AuthProvider
+	sshChecks    *sshChecks
+	pings        *pendingPings
 
 	pollNetMapStreamWG sync.WaitGroup
 }
@@ -129,6 +148,8 @@ func NewHeadscale(cfg *types.Config) (*Headscale, error) {
 		cfg:                cfg,
 		noisePrivateKey:    noisePrivateKey,
 		registrationCache:  registrationCache,
+		sshChecks:          newSSHChecks(),
+		pings:              newPendingPings(),
 		pollNetMapStreamWG: sync.WaitGroup{},
 		nodeNotifier:       notifier.NewNotifier(cfg),
 	}
@@ -150,11 +171,15 @@ func NewHeadscale(cfg *types.Config) (*Headscale, error) {
 	app.ephemeralGC = db.NewEphemeralGarbageCollector(func(ni types.NodeID) {
 		if err := app.db.DeleteEphemeralNode(ni); err != nil {
 			log.Err(err).Uint64("node.id", ni.Uint64()).Msgf("failed to delete ephemeral node")
+
+			return
 		}
+
+		app.nodeNotifier.NodesChanged()
 	})
 
 	var authProvider AuthProvider
-	authProvider = NewAuthProviderWeb(cfg.ServerURL)
+	authProvider = NewAuthProviderWeb(cfg.ServerURL, app.sshChecks)
 	if cfg.OIDC.Issuer != "" {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
@@ -165,6 +190,8 @@ func NewHeadscale(cfg *types.Config) (*Headscale, error) {
 			app.db,
 			app.nodeNotifier,
 			app.ipAlloc,
+			app.expiryUpdate,
+			app.sshChecks,
 		)
 		if err != nil {
 			if cfg.OIDC.OnlyStartIfOIDCIsAvailable {
@@ -178,6 +205,10 @@ func NewHeadscale(cfg *types.Config) (*Headscale, error) {
 	}
 	app.authProvider = authProvider
 
+	if cfg.Policy.Mode == types.PolicyModeURL {
+		app.policyLoader = policy.NewURLLoader(cfg.Policy.URL, cfg.Policy.BearerToken)
+	}
+
 	if app.cfg.DNSConfig != nil && app.cfg.DNSConfig.Proxied { // if MagicDNS
 		// TODO(kradalby): revisit why this takes a list.
 
@@ -258,13 +289,34 @@ func (h *Headscale) expireExpiredNodes(ctx context.Context, every time.Duration)
 			if changed {
 				log.Trace().Interface("nodes", update.ChangePatches).Msgf("expiring nodes")
 
+				h.nodeNotifier.NodesChanged()
+
 				ctx := types.NotifyCtx(context.Background(), "expire-expired", "na")
-				h.nodeNotifier.NotifyAll(ctx, update)
+				h.nodeNotifier.NotifyAll(ctx, h.expiryUpdate(update))
 			}
 		}
 	}
 }
 
+// expiryUpdate returns the update to send to the peers of the nodes in
+// the key expiry patches. If a posture of the policy depends on the key
+// expiry, the packet filters change with it, the peers are sent the
+// changed nodes along with their packet filter instead.
+func (h *Headscale) expiryUpdate(update types.StateUpdate) types.StateUpdate {
+	if !h.ACLPolicy().UsesKeyExpiry() {
+		return update
+	}
+
+	changed := types.StateUpdate{
+		Type: types.StatePeerChanged,
+	}
+	for _, patch := range update.ChangePatches {
+		changed.ChangeNodes = append(changed.ChangeNodes, types.NodeID(patch.NodeID))
+	}
+
+	return changed
+}
+
 // scheduledDERPMapUpdateWorker refreshes the DERPMap stored on the global object
 // at a set interval.
 func (h *Headscale) scheduledDERPMapUpdateWorker(cancelChan <-chan struct{}) {
@@ -295,6 +347,195 @@ func (h *Headscale) scheduledDERPMapUpdateWorker(cancelChan <-chan struct{}) {
 	}
 }
 
+// scheduledPolicyUpdateWorker fetches the policy from its URL, and applies
+// it if it changed and is valid. Otherwise the last valid policy is kept.
+func (h *Headscale) scheduledPolicyUpdateWorker(cancelChan <-chan struct{}) {
+	log.Info().
+		Dur("frequency", h.cfg.Policy.RefreshInterval).
+		Str("url", h.cfg.Policy.URL).
+		Msg("Setting up a policy update worker")
+	ticker := time.NewTicker(h.cfg.Policy.RefreshInterval)
+
+	for {
+		select {
+		case <-cancelChan:
+			return
+
+		case <-ticker.C:
+			log.Debug().Msg("Fetching policy updates")
+
+			if !h.updatePolicyFromURL() {
+				continue
+			}
+
+			log.Info().
+				Msg("ACL policy successfully updated from URL, notifying nodes of change")
+
+			ctx := types.NotifyCtx(context.Background(), "acl-url-update", "na")
+			h.nodeNotifier.NotifyAll(ctx, types.StateUpdate{
+				Type: types.StateFullUpdate,
+			})
+		}
+	}
+}
+
+// updatePolicyFromURL fetches the policy from its URL and applies it,
+// reporting if it changed.
+func (h *Headscale) updatePolicyFromURL() bool {
+	h.policyMu.Lock()
+	defer h.policyMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), types.HTTPTimeout)
+	defer cancel()
+
+	pol, changed, err := h.policyLoader.Load(ctx, h.validateACLPolicy)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to fetch ACL policy, keeping the current policy")
+
+		return false
+	}
+
+	if !changed {
+		return false
+	}
+
+	if err := h.setACLPolicy(pol); err != nil {
+		log.Error().Err(err).Msg("failed to apply fetched ACL policy, keeping the current policy")
+
+		return false
+	}
+	h.policyLoaded(h.policyLoader.Data())
+
+	return true
+}
+
+// policyFileWatcher reloads the policy file when it changes. Changes are
+// debounced, as editors and deployment tools often write a file in several
+// steps, and the policy is only reloaded if its content changed. A policy
+// that is not valid is rejected and the current policy is kept.
+// If not nil, watching is closed once the file is watched, and reloaded
+// receives the result of every reload.
+func (h *Headscale) policyFileWatcher(
+	cancelChan <-chan struct{},
+	watching chan<- struct{},
+	reloaded chan<- error,
+) {
+	path := util.AbsolutePathFromConfigPath(h.cfg.Policy.Path)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create policy file watcher, the policy is only reloaded on SIGHUP")
+
+		return
+	}
+	defer watcher.Close()
+
+	// Watch the directory instead of the file, so the policy is still
+	// watched after being replaced by a rename, as many tools do.
+	err = watcher.Add(filepath.Dir(path))
+	if err != nil {
+		log.Error().Err(err).Str("path", path).Msg("failed to watch policy file, the policy is only reloaded on SIGHUP")
+
+		return
+	}
+
+	// If the policy file is a symlink, like in a Kubernetes ConfigMap, the
+	// link target is replaced instead of the file, so any change in the
+	// directory can be a change of the policy.
+	info, err := os.Lstat(path)
+	isSymlink := err == nil && info.Mode()&os.ModeSymlink != 0
+
+	log.Info().
+		Str("path", path).
+		Msg("Watching policy file for changes")
+
+	debounce := time.NewTimer(policyReloadDebounce)
+	debounce.Stop()
+
+	if watching != nil {
+		close(watching)
+	}
+
+	for {
+		select {
+		case <-cancelChan:
+			debounce.Stop()
+
+			return
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if isSymlink || filepath.Clean(event.Name) == path {
+				debounce.Reset(policyReloadDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+
+			log.Error().Err(err).Msg("policy file watcher error")
+
+		case <-debounce.C:
+			err := h.reloadPolicyFile(path)
+			if reloaded != nil {
+				reloaded <- err
+			}
+		}
+	}
+}
+
+// reloadPolicyFile loads the policy file if its content changed since the
+// last successful load, and sends the new policy to all nodes.
+func (h *Headscale) reloadPolicyFile(path string) error {
+	changed, err := h.reloadChangedPolicyFile(path)
+	if err != nil {
+		log.Error().Err(err).Str("path", path).Msg("rejected changed policy file, keeping the current policy")
+
+		return err
+	}
+
+	if !changed {
+		return nil
+	}
+
+	log.Info().
+		Str("path", path).
+		Msg("ACL policy file changed and successfully reloaded, notifying nodes of change")
+
+	ctx := types.NotifyCtx(context.Background(), "acl-file-change", "na")
+	h.nodeNotifier.NotifyAll(ctx, types.StateUpdate{
+		Type: types.StateFullUpdate,
+	})
+
+	return nil
+}
+
+// reloadChangedPolicyFile loads the policy file, unless its content is the
+// one of the current policy, and reports if it was loaded.
+func (h *Headscale) reloadChangedPolicyFile(path string) (bool, error) {
+	h.policyMu.Lock()
+	defer h.policyMu.Unlock()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	if policyHash(data) == h.policyHash {
+		return false, nil
+	}
+
+	if err := h.loadACLPolicyLocked(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (h *Headscale) grpcAuthenticationInterceptor(ctx context.Context,
 	req interface{},
 	info *grpc.UnaryServerInfo,
@@ -443,6 +684,7 @@ func (h *Headscale) createRouter(grpcMux *grpcRuntime.ServeMux) *mux.Router {
 	router.HandleFunc("/health", h.HealthHandler).Methods(http.MethodGet)
 	router.HandleFunc("/key", h.KeyHandler).Methods(http.MethodGet)
 	router.HandleFunc("/register/{mkey}", h.authProvider.RegisterHandler).Methods(http.MethodGet)
+	router.HandleFunc("/ssh/check/{check_id}", h.authProvider.SSHCheckHandler).Methods(http.MethodGet)
 
 	if provider, ok := h.authProvider.(*AuthProviderOIDC); ok {
 		router.HandleFunc("/oidc/callback", provider.OIDCCallbackHandler).Methods(http.MethodGet)
@@ -526,6 +768,18 @@ func (h *Headscale) Serve() error {
 		go h.scheduledDERPMapUpdateWorker(derpMapCancelChannel)
 	}
 
+	if h.policyLoader != nil {
+		policyCancelChannel := make(chan struct{})
+		defer func() { policyCancelChannel <- struct{}{} }()
+		go h.scheduledPolicyUpdateWorker(policyCancelChannel)
+	}
+
+	if h.cfg.Policy.Mode == types.PolicyModeFile && h.cfg.Policy.Path != "" {
+		policyWatcherCancelChannel := make(chan struct{})
+		defer func() { policyWatcherCancelChannel <- struct{}{} }()
+		go h.policyFileWatcher(policyWatcherCancelChannel, nil, nil)
+	}
+
 	if len(h.DERPMap.Regions) == 0 {
 		return errEmptyInitialDERPMap
 	}
@@ -547,6 +801,41 @@ func (h *Headscale) Serve() error {
 	defer expireNodeCancel()
 	go h.expireExpiredNodes(expireNodeCtx, updateInterval)
 
+	expireAccessGrantsCtx, expireAccessGrantsCancel := context.WithCancel(context.Background())
+	defer expireAccessGrantsCancel()
+	go h.expireAccessGrants(expireAccessGrantsCtx, updateInterval)
+
+	clientVersionCtx, clientVersionCancel := context.WithTimeout(context.Background(), types.HTTPTimeout)
+	err = h.updateClientVersion(clientVersionCtx, true)
+	clientVersionCancel()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load the latest client version")
+	}
+
+	if err := h.updateDNSRecords("dns-records-loaded"); err != nil {
+		return err
+	}
+
+	expireDNSRecordsCtx, expireDNSRecordsCancel := context.WithCancel(context.Background())
+	defer expireDNSRecordsCancel()
+	go h.expireDNSRecords(expireDNSRecordsCtx, updateInterval)
+
+	if err := h.loadBroadcastMessage(); err != nil {
+		return fmt.Errorf("failed to load the broadcast message: %w", err)
+	}
+
+	if h.cfg.Health.KeyExpiryWarning > 0 {
+		warnExpiringKeysCtx, warnExpiringKeysCancel := context.WithCancel(context.Background())
+		defer warnExpiringKeysCancel()
+		go h.warnExpiringKeys(warnExpiringKeysCtx, updateInterval)
+	}
+
+	if h.cfg.ClientVersion.Path != "" || h.cfg.ClientVersion.URL != "" {
+		clientVersionUpdateCtx, clientVersionUpdateCancel := context.WithCancel(context.Background())
+		defer clientVersionUpdateCancel()
+		go h.scheduledClientVersionUpdateWorker(clientVersionUpdateCtx, h.cfg.ClientVersion.RefreshInterval)
+	}
+
 	if zl.GlobalLevel() == zl.TraceLevel {
 		zerolog.RespLog = true
 	} else {
@@ -773,9 +1062,7 @@ func (h *Headscale) Serve() error {
 				// TODO(kradalby): Reload config on SIGHUP
 				if err := h.loadACLPolicy(); err != nil {
 					log.Error().Err(err).Msg("failed to reload ACL policy")
-				}
-
-				if h.ACLPolicy != nil {
+				} else if h.ACLPolicy() != nil {
 					log.Info().
 						Msg("ACL policy successfully reloaded, notifying nodes of change")
 
@@ -994,10 +1281,27 @@ func readOrCreatePrivateKey(path string) (*key.MachinePrivate, error) {
 	return &machineKey, nil
 }
 
+// ACLPolicy returns the current policy, nil if there is none.
+func (h *Headscale) ACLPolicy() *policy.ACLPolicy {
+	return h.aclPolicy.Load()
+}
+
+// loadACLPolicy loads the policy of the configured mode and applies it.
+// Reloads are serialised, so a slow reload cannot overwrite the policy
+// applied by a later one.
 func (h *Headscale) loadACLPolicy() error {
+	h.policyMu.Lock()
+	defer h.policyMu.Unlock()
+
+	return h.loadACLPolicyLocked()
+}
+
+// loadACLPolicyLocked is loadACLPolicy for callers holding policyMu.
+func (h *Headscale) loadACLPolicyLocked() error {
 	var (
-		pol *policy.ACLPolicy
-		err error
+		pol  *policy.ACLPolicy
+		data []byte
+		err  error
 	)
 
 	switch h.cfg.Policy.Mode {
@@ -1010,35 +1314,29 @@ func (h *Headscale) loadACLPolicy() error {
 		}
 
 		absPath := util.AbsolutePathFromConfigPath(path)
-		pol, err = policy.LoadACLPolicyFromPath(absPath)
+		data, err = os.ReadFile(absPath)
 		if err != nil {
 			return fmt.Errorf("failed to load ACL policy from file: %w", err)
 		}
 
-		// Validate and reject configuration that would error when applied
-		// when creating a map response. This requires nodes, so there is still
-		// a scenario where they might be allowed if the server has no nodes
-		// yet, but it should help for the general case and for hot reloading
-		// configurations.
-		// Note that this check is only done for file-based policies in this function
-		// as the database-based policies are checked in the gRPC API where it is not
-		// allowed to be written to the database.
-		nodes, err := h.db.ListNodes()
+		pol, err = policy.LoadACLPolicyFromBytes(data)
 		if err != nil {
-			return fmt.Errorf("loading nodes from database to validate policy: %w", err)
+			return fmt.Errorf("failed to load ACL policy from file: %w", err)
 		}
 
-		_, err = pol.CompileFilterRules(nodes)
-		if err != nil {
-			return fmt.Errorf("verifying policy rules: %w", err)
+		if err := h.validateACLPolicy(pol); err != nil {
+			return err
 		}
 
-		if len(nodes) > 0 {
-			_, err = pol.CompileSSHPolicy(nodes[0], nodes)
-			if err != nil {
-				return fmt.Errorf("verifying SSH rules: %w", err)
-			}
+	case types.PolicyModeURL:
+		ctx, cancel := context.WithTimeout(context.Background(), types.HTTPTimeout)
+		defer cancel()
+
+		pol, _, err = h.policyLoader.Load(ctx, h.validateACLPolicy)
+		if err != nil {
+			return fmt.Errorf("failed to load ACL policy from URL: %w", err)
 		}
+		data = h.policyLoader.Data()
 
 	case types.PolicyModeDB:
 		p, err := h.db.GetPolicy()
@@ -1050,7 +1348,8 @@ func (h *Headscale) loadACLPolicy() error {
 			return fmt.Errorf("failed to get policy from database: %w", err)
 		}
 
-		pol, err = policy.LoadACLPolicyFromBytes([]byte(p.Data))
+		data = []byte(p.Data)
+		pol, err = policy.LoadACLPolicyFromBytes(data)
 		if err != nil {
 			return fmt.Errorf("failed to parse policy: %w", err)
 		}
@@ -1060,7 +1359,80 @@ func (h *Headscale) loadACLPolicy() error {
 			Msg("Unknown ACL policy mode")
 	}
 
-	h.ACLPolicy = pol
+	if err := h.setACLPolicy(pol); err != nil {
+		return err
+	}
+
+	if pol != nil {
+		h.policyLoaded(data)
+	}
+
+	return nil
+}
+
+// policyLoaded records the hash and time of a successfully loaded policy.
+// The caller must hold policyMu.
+func (h *Headscale) policyLoaded(data []byte) {
+	h.policyHash = policyHash(data)
+
+	policyLastLoadSeconds.SetToCurrentTime()
+	policyInfo.Reset()
+	policyInfo.WithLabelValues(h.policyHash).Set(1)
+}
+
+func policyHash(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// validateACLPolicy validates and rejects configuration that would error
+// when applied when creating a map response. This requires nodes, so there
+// is still a scenario where they might be allowed if the server has no nodes
+// yet, but it should help for the general case and for hot reloading
+// configurations. It is used for every policy mode, the database-based
+// policy is checked in the gRPC API before it is written to the database.
+// Errors caused by the policy wrap errInvalidPolicy.
+func (h *Headscale) validateACLPolicy(pol *policy.ACLPolicy) error {
+	nodes, err := h.db.ListNodes()
+	if err != nil {
+		return fmt.Errorf("loading nodes from database to validate policy: %w", err)
+	}
+
+	_, err = pol.CompileFilterRules(nodes)
+	if err != nil {
+		return fmt.Errorf("%w: verifying policy rules: %w", errInvalidPolicy, err)
+	}
+
+	if len(nodes) > 0 {
+		_, err = pol.CompileSSHPolicy(nodes[0], nodes)
+		if err != nil {
+			return fmt.Errorf("%w: verifying SSH rules: %w", errInvalidPolicy, err)
+		}
+
+		_, err = pol.CompileNodeCapMap(nodes[0], nil)
+		if err != nil {
+			return fmt.Errorf("%w: verifying node attributes: %w", errInvalidPolicy, err)
+		}
+	}
+
+	err = pol.RunTests(nodes)
+	if err != nil {
+		return fmt.Errorf("%w: verifying policy tests: %w", errInvalidPolicy, err)
+	}
+
+	// The access grants are kept with the new policy, a grant referring
+	// to a group or tag removed from it would be left out.
+	grants, err := h.db.ListAccessGrants()
+	if err != nil {
+		return fmt.Errorf("loading access grants from database to validate policy: %w", err)
+	}
+
+	for _, grant := range grants {
+		if err := pol.ValidateAccessGrant(grant, nodes); err != nil {
+			return fmt.Errorf("%w: verifying access grant %d: %w", errInvalidPolicy, grant.ID, err)
+		}
+	}
 
 	return nil
 }
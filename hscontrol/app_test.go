@@ -0,0 +1,87 @@
+package hscontrol
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/policy"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"gopkg.in/check.v1"
+)
+
+func (s *Suite) TestPolicyFileWatcher(c *check.C) {
+	path := filepath.Join(tmpDir, "acl.hujson")
+	write := func(policy string) {
+		err := os.WriteFile(path, []byte(policy), 0o600)
+		c.Assert(err, check.IsNil)
+	}
+
+	write(`{"groups": {"group:admins": ["user1"]}}`)
+
+	app.cfg.Policy = types.PolicyConfig{
+		Mode: types.PolicyModeFile,
+		Path: path,
+	}
+
+	err := app.loadACLPolicy()
+	c.Assert(err, check.IsNil)
+	c.Assert(app.ACLPolicy().Groups, check.HasLen, 1)
+
+	hash := app.policyHash
+	c.Assert(hash, check.Equals, policyHash([]byte(`{"groups": {"group:admins": ["user1"]}}`)))
+
+	cancel := make(chan struct{})
+	defer func() { cancel <- struct{}{} }()
+	watching := make(chan struct{})
+	reloaded := make(chan error)
+	go app.policyFileWatcher(cancel, watching, reloaded)
+
+	<-watching
+
+	// A policy that cannot be parsed is rejected.
+	write(`{"groups": {`)
+	c.Assert(<-reloaded, check.NotNil)
+	c.Assert(app.policyHash, check.Equals, hash)
+	c.Assert(app.ACLPolicy().Groups, check.HasLen, 1)
+
+	// A valid policy replacing the file is loaded.
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, []byte(`{"groups": {"group:admins": ["user1"], "group:devs": ["user2"]}}`), 0o600)
+	c.Assert(err, check.IsNil)
+	err = os.Rename(tmp, path)
+	c.Assert(err, check.IsNil)
+
+	c.Assert(<-reloaded, check.IsNil)
+	c.Assert(app.policyHash, check.Not(check.Equals), hash)
+	c.Assert(app.ACLPolicy().Groups, check.HasLen, 2)
+}
+
+func (s *Suite) TestValidateACLPolicyKeepsAccessGrants(c *check.C) {
+	err := app.db.CreateAccessGrant(&types.AccessGrant{
+		Sources:      []string{"*"},
+		Destinations: []string{"tag:server"},
+		Ports:        "22",
+		Expiration:   time.Now().Add(time.Hour),
+	})
+	c.Assert(err, check.IsNil)
+
+	pol, err := policy.LoadACLPolicyFromBytes([]byte(`{"groups": {"group:admins": ["user1"]}, "tagOwners": {"tag:server": ["group:admins"]}}`))
+	c.Assert(err, check.IsNil)
+	c.Assert(app.validateACLPolicy(pol), check.IsNil)
+
+	// A policy file removing the tag of an active grant is rejected like
+	// it is by the API.
+	path := filepath.Join(tmpDir, "acl.hujson")
+	err = os.WriteFile(path, []byte(`{"groups": {"group:admins": ["user1"]}}`), 0o600)
+	c.Assert(err, check.IsNil)
+
+	app.cfg.Policy = types.PolicyConfig{
+		Mode: types.PolicyModeFile,
+		Path: path,
+	}
+
+	err = app.loadACLPolicy()
+	c.Assert(errors.Is(err, errInvalidPolicy), check.Equals, true, check.Commentf("%v", err))
+}
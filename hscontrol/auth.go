@@ -21,6 +21,11 @@ import (
 type AuthProvider interface {
 	RegisterHandler(http.ResponseWriter, *http.Request)
 	AuthURL(key.MachinePublic) string
+
+	// SSHCheckHandler lets the user of the source node of an SSH
+	// connection held by a check action re-authenticate, and approves it.
+	SSHCheckHandler(http.ResponseWriter, *http.Request)
+	SSHCheckURL(checkID string) string
 }
 
 func logAuthFunc(
@@ -338,6 +343,8 @@ func (h *Headscale) handleAuthKey(
 			}
 		}
 
+		h.nodeNotifier.NodesChanged()
+
 		ctx := types.NotifyCtx(context.Background(), "handle-authkey", "na")
 		h.nodeNotifier.NotifyAll(ctx, types.StateUpdate{Type: types.StatePeerChanged, ChangeNodes: []types.NodeID{node.ID}})
 	} else {
@@ -384,6 +391,8 @@ func (h *Headscale) handleAuthKey(
 
 			return
 		}
+
+		h.nodeNotifier.NodesChanged()
 	}
 
 	err = h.db.Write(func(tx *gorm.DB) error {
@@ -489,8 +498,10 @@ func (h *Headscale) handleNodeLogOut(
 		return
 	}
 
+	h.nodeNotifier.NodesChanged()
+
 	ctx := types.NotifyCtx(context.Background(), "logout-expiry", "na")
-	h.nodeNotifier.NotifyWithIgnore(ctx, types.StateUpdateExpire(node.ID, now), node.ID)
+	h.nodeNotifier.NotifyWithIgnore(ctx, h.expiryUpdate(types.StateUpdateExpire(node.ID, now)), node.ID)
 
 	resp.AuthURL = ""
 	resp.MachineAuthorized = false
@@ -528,6 +539,8 @@ func (h *Headscale) handleNodeLogOut(
 				Msg("Cannot delete ephemeral node from the database")
 		}
 
+		h.nodeNotifier.NodesChanged()
+
 		ctx := types.NotifyCtx(context.Background(), "logout-ephemeral", "na")
 		h.nodeNotifier.NotifyAll(ctx, types.StateUpdate{
 			Type:    types.StatePeerRemoved,
@@ -0,0 +1,167 @@
+package hscontrol
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/rs/zerolog/log"
+	"tailscale.com/version"
+)
+
+const maxClientVersionSize = 1 << 20
+
+var (
+	ErrInvalidClientVersion = errors.New("invalid client version")
+	ErrClientVersionFetch   = errors.New("unexpected response fetching the latest client version")
+)
+
+// validateClientVersion checks that the version is a Tailscale release
+// version, like 1.76.1.
+func validateClientVersion(v string) error {
+	// AtLeast is false for anything that does not parse as a version.
+	if !version.AtLeast(v, "0") {
+		return fmt.Errorf("%w: %q", ErrInvalidClientVersion, v)
+	}
+
+	return nil
+}
+
+// parseClientVersion parses the latest client version from either the
+// version itself, or JSON with a Version field, like the one served by
+// https://pkgs.tailscale.com/stable/?mode=json.
+func parseClientVersion(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+
+	v := string(data)
+	if bytes.HasPrefix(data, []byte("{")) {
+		var pkgs struct {
+			Version string
+		}
+		if err := json.Unmarshal(data, &pkgs); err != nil {
+			return "", fmt.Errorf("%w: %s", ErrInvalidClientVersion, err)
+		}
+
+		v = pkgs.Version
+	}
+
+	v = strings.TrimPrefix(v, "v")
+	if err := validateClientVersion(v); err != nil {
+		return "", err
+	}
+
+	return v, nil
+}
+
+// fetchClientVersion reads the latest client version from the configured
+// file or URL. It returns an empty version if neither is configured.
+func (h *Headscale) fetchClientVersion(ctx context.Context) (string, error) {
+	var data []byte
+
+	switch {
+	case h.cfg.ClientVersion.Path != "":
+		var err error
+		data, err = os.ReadFile(h.cfg.ClientVersion.Path)
+		if err != nil {
+			return "", err
+		}
+
+	case h.cfg.ClientVersion.URL != "":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.cfg.ClientVersion.URL, nil)
+		if err != nil {
+			return "", err
+		}
+
+		client := &http.Client{Timeout: types.HTTPTimeout}
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("%w: %s", ErrClientVersionFetch, resp.Status)
+		}
+
+		data, err = io.ReadAll(io.LimitReader(resp.Body, maxClientVersionSize))
+		if err != nil {
+			return "", fmt.Errorf("reading the latest client version: %w", err)
+		}
+
+	default:
+		return "", nil
+	}
+
+	return parseClientVersion(data)
+}
+
+// updateClientVersion sets the latest client version advertised to the
+// nodes: the one set through the API, or else the one read from the
+// configured file or URL. The file or URL is only read again if refresh
+// is set, the last version read is kept if that fails. The nodes are
+// sent a full update when the version changes.
+func (h *Headscale) updateClientVersion(ctx context.Context, refresh bool) error {
+	h.clientVersionMu.Lock()
+	defer h.clientVersionMu.Unlock()
+
+	var fetchErr error
+	if refresh {
+		v, err := h.fetchClientVersion(ctx)
+		if err != nil {
+			fetchErr = fmt.Errorf("reading the latest client version: %w", err)
+		} else {
+			h.fetchedClientVersion = v
+		}
+	}
+
+	latest := h.fetchedClientVersion
+
+	set, err := h.db.GetClientVersion()
+	switch {
+	case err == nil && set.Version != "":
+		latest = set.Version
+	case err != nil && !errors.Is(err, types.ErrClientVersionNotFound):
+		return err
+	}
+
+	if latest != h.mapper.LatestClientVersion() {
+		log.Info().Str("version", latest).Msg("Latest client version changed")
+
+		h.mapper.SetLatestClientVersion(latest)
+
+		notifyCtx := types.NotifyCtx(context.Background(), "client-version-changed", "na")
+		h.nodeNotifier.NotifyAll(notifyCtx, types.StateUpdate{
+			Type: types.StateFullUpdate,
+		})
+	}
+
+	return fetchErr
+}
+
+// scheduledClientVersionUpdateWorker reads the latest client version from
+// the configured file or URL at a set interval.
+func (h *Headscale) scheduledClientVersionUpdateWorker(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+
+	for {
+		select {
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		case <-ticker.C:
+			fetchCtx, cancel := context.WithTimeout(ctx, types.HTTPTimeout)
+			if err := h.updateClientVersion(fetchCtx, true); err != nil {
+				log.Error().Err(err).Msg("failed to update the latest client version")
+			}
+			cancel()
+		}
+	}
+}
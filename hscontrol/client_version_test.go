@@ -0,0 +1,72 @@
+package hscontrol
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/juanfont/headscale/hscontrol/mapper"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"gopkg.in/check.v1"
+	"tailscale.com/tailcfg"
+)
+
+func (s *Suite) TestParseClientVersion(c *check.C) {
+	tests := []struct {
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{data: "1.76.1\n", want: "1.76.1"},
+		{data: "v1.76.1", want: "1.76.1"},
+		{data: `{"TarballsVersion": "1.76.1", "Version": "1.76.1"}`, want: "1.76.1"},
+		{data: `{"Tarballs": {}}`, wantErr: true},
+		{data: `{"Version": `, wantErr: true},
+		{data: "<html>", wantErr: true},
+		{data: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseClientVersion([]byte(tt.data))
+		if tt.wantErr {
+			c.Assert(err, check.NotNil, check.Commentf("data %q", tt.data))
+
+			continue
+		}
+
+		c.Assert(err, check.IsNil, check.Commentf("data %q", tt.data))
+		c.Assert(got, check.Equals, tt.want)
+	}
+}
+
+func (s *Suite) TestUpdateClientVersion(c *check.C) {
+	path := filepath.Join(tmpDir, "client_version")
+	app.cfg.ClientVersion.Path = path
+	app.mapper = mapper.NewMapper(app.db, app.cfg, &tailcfg.DERPMap{}, app.nodeNotifier)
+
+	ctx := context.Background()
+
+	// Nothing is advertised until the version is known.
+	c.Assert(app.updateClientVersion(ctx, true), check.NotNil)
+	c.Assert(app.mapper.LatestClientVersion(), check.Equals, "")
+
+	c.Assert(os.WriteFile(path, []byte("1.76.0\n"), 0o600), check.IsNil)
+	c.Assert(app.updateClientVersion(ctx, true), check.IsNil)
+	c.Assert(app.mapper.LatestClientVersion(), check.Equals, "1.76.0")
+
+	// The last version read is kept if the file becomes invalid.
+	c.Assert(os.WriteFile(path, []byte("garbage"), 0o600), check.IsNil)
+	c.Assert(app.updateClientVersion(ctx, true), check.NotNil)
+	c.Assert(app.mapper.LatestClientVersion(), check.Equals, "1.76.0")
+
+	// A version set through the API takes precedence, until it is unset.
+	_, err := app.db.SetClientVersion("1.78.0", types.PolicyAuthorSocket)
+	c.Assert(err, check.IsNil)
+	c.Assert(app.updateClientVersion(ctx, false), check.IsNil)
+	c.Assert(app.mapper.LatestClientVersion(), check.Equals, "1.78.0")
+
+	_, err = app.db.SetClientVersion("", types.PolicyAuthorSocket)
+	c.Assert(err, check.IsNil)
+	c.Assert(app.updateClientVersion(ctx, false), check.IsNil)
+	c.Assert(app.mapper.LatestClientVersion(), check.Equals, "1.76.0")
+}
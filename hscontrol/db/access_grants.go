@@ -0,0 +1,65 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"gorm.io/gorm"
+)
+
+// CreateAccessGrant stores a new access grant.
+func (hsdb *HSDatabase) CreateAccessGrant(grant *types.AccessGrant) error {
+	return hsdb.DB.Create(grant).Error
+}
+
+// ListAccessGrants returns the access grants that have not expired, the
+// first to expire first.
+func (hsdb *HSDatabase) ListAccessGrants() ([]types.AccessGrant, error) {
+	var grants []types.AccessGrant
+	if err := hsdb.DB.
+		Where("expiration > ?", time.Now()).
+		Order("expiration, id").
+		Find(&grants).Error; err != nil {
+		return nil, err
+	}
+
+	return grants, nil
+}
+
+// DeleteAccessGrant deletes the access grant with the given ID.
+func (hsdb *HSDatabase) DeleteAccessGrant(id uint64) (*types.AccessGrant, error) {
+	var grant types.AccessGrant
+	if err := hsdb.DB.First(&grant, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrAccessGrantNotFound
+		}
+
+		return nil, err
+	}
+
+	if err := hsdb.DB.Delete(&grant).Error; err != nil {
+		return nil, err
+	}
+
+	return &grant, nil
+}
+
+// DeleteExpiredAccessGrants deletes the access grants that expired before
+// now, and returns them.
+func (hsdb *HSDatabase) DeleteExpiredAccessGrants(now time.Time) ([]types.AccessGrant, error) {
+	var expired []types.AccessGrant
+	if err := hsdb.DB.Where("expiration <= ?", now).Find(&expired).Error; err != nil {
+		return nil, err
+	}
+
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	if err := hsdb.DB.Delete(&expired).Error; err != nil {
+		return nil, err
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessGrants(t *testing.T) {
+	db, err := newTestDB()
+	require.NoError(t, err)
+
+	now := time.Now()
+	later := &types.AccessGrant{
+		Sources:      []string{"alice"},
+		Destinations: []string{"tag:prod"},
+		Ports:        "22",
+		Reason:       "incident 42",
+		Author:       types.PolicyAuthorSocket,
+		Expiration:   now.Add(2 * time.Hour),
+	}
+	sooner := &types.AccessGrant{
+		Sources:      []string{"bob"},
+		Destinations: []string{"10.0.0.0/8"},
+		Ports:        "*",
+		Expiration:   now.Add(time.Hour),
+	}
+	expired := &types.AccessGrant{
+		Sources:      []string{"carol"},
+		Destinations: []string{"tag:prod"},
+		Ports:        "443",
+		Expiration:   now.Add(-time.Minute),
+	}
+
+	for _, grant := range []*types.AccessGrant{later, sooner, expired} {
+		require.NoError(t, db.CreateAccessGrant(grant))
+	}
+
+	grants, err := db.ListAccessGrants()
+	require.NoError(t, err)
+	require.Len(t, grants, 2)
+	assert.Equal(t, sooner.ID, grants[0].ID)
+	assert.Equal(t, later.ID, grants[1].ID)
+	assert.Equal(t, []string{"tag:prod"}, grants[1].Destinations)
+	assert.Equal(t, "incident 42", grants[1].Reason)
+
+	deleted, err := db.DeleteExpiredAccessGrants(now)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, expired.ID, deleted[0].ID)
+
+	revoked, err := db.DeleteAccessGrant(sooner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob"}, revoked.Sources)
+
+	_, err = db.DeleteAccessGrant(sooner.ID)
+	require.ErrorIs(t, err, types.ErrAccessGrantNotFound)
+
+	grants, err = db.ListAccessGrants()
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, later.ID, grants[0].ID)
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SetBroadcastMessage stores the message broadcast to the clients. An
+// empty message clears it.
+func (hsdb *HSDatabase) SetBroadcastMessage(message, author string) (*types.BroadcastMessage, error) {
+	broadcastMessage := types.BroadcastMessage{
+		Message: message,
+		Author:  author,
+	}
+
+	if err := hsdb.DB.Clauses(clause.Returning{}).Create(&broadcastMessage).Error; err != nil {
+		return nil, err
+	}
+
+	return &broadcastMessage, nil
+}
+
+// GetBroadcastMessage returns the last message broadcast to the clients.
+func (hsdb *HSDatabase) GetBroadcastMessage() (*types.BroadcastMessage, error) {
+	var broadcastMessage types.BroadcastMessage
+	if err := hsdb.DB.
+		Order("id DESC").
+		Limit(1).
+		First(&broadcastMessage).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrBroadcastMessageNotFound
+		}
+
+		return nil, err
+	}
+
+	return &broadcastMessage, nil
+}
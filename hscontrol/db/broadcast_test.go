@@ -0,0 +1,33 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastMessage(t *testing.T) {
+	db, err := newTestDB()
+	require.NoError(t, err)
+
+	_, err = db.GetBroadcastMessage()
+	require.ErrorIs(t, err, types.ErrBroadcastMessageNotFound)
+
+	_, err = db.SetBroadcastMessage("Maintenance tonight", types.PolicyAuthorSocket)
+	require.NoError(t, err)
+
+	got, err := db.GetBroadcastMessage()
+	require.NoError(t, err)
+	assert.Equal(t, "Maintenance tonight", got.Message)
+	assert.Equal(t, types.PolicyAuthorSocket, got.Author)
+
+	_, err = db.SetBroadcastMessage("", "hskey-abc")
+	require.NoError(t, err)
+
+	got, err = db.GetBroadcastMessage()
+	require.NoError(t, err)
+	assert.Empty(t, got.Message)
+	assert.Equal(t, "hskey-abc", got.Author)
+}
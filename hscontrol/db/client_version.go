@@ -0,0 +1,41 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SetClientVersion sets the latest client version in the database. An
+// empty version unsets it.
+func (hsdb *HSDatabase) SetClientVersion(version, author string) (*types.ClientVersion, error) {
+	clientVersion := types.ClientVersion{
+		Version: version,
+		Author:  author,
+	}
+
+	if err := hsdb.DB.Clauses(clause.Returning{}).Create(&clientVersion).Error; err != nil {
+		return nil, err
+	}
+
+	return &clientVersion, nil
+}
+
+// GetClientVersion returns the latest client version set in the database.
+func (hsdb *HSDatabase) GetClientVersion() (*types.ClientVersion, error) {
+	var clientVersion types.ClientVersion
+	if err := hsdb.DB.
+		Order("id DESC").
+		Limit(1).
+		First(&clientVersion).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrClientVersionNotFound
+		}
+
+		return nil, err
+	}
+
+	return &clientVersion, nil
+}
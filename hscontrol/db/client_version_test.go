@@ -0,0 +1,37 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientVersion(t *testing.T) {
+	db, err := newTestDB()
+	require.NoError(t, err)
+
+	_, err = db.GetClientVersion()
+	require.ErrorIs(t, err, types.ErrClientVersionNotFound)
+
+	_, err = db.SetClientVersion("1.74.1", types.PolicyAuthorSocket)
+	require.NoError(t, err)
+
+	set, err := db.SetClientVersion("1.76.0", "hskey-abc")
+	require.NoError(t, err)
+	assert.NotZero(t, set.ID)
+
+	got, err := db.GetClientVersion()
+	require.NoError(t, err)
+	assert.Equal(t, "1.76.0", got.Version)
+	assert.Equal(t, "hskey-abc", got.Author)
+
+	// Unsetting the version is recorded as an empty version.
+	_, err = db.SetClientVersion("", types.PolicyAuthorSocket)
+	require.NoError(t, err)
+
+	got, err = db.GetClientVersion()
+	require.NoError(t, err)
+	assert.Empty(t, got.Version)
+}
@@ -485,6 +485,55 @@ func NewHeadscaleDatabase(
 				},
 				Rollback: func(db *gorm.DB) error { return nil },
 			},
+			// Add the OIDC groups of users, to be able to use
+			// them in the policy.
+			{
+				ID: "202610171000",
+				Migrate: func(tx *gorm.DB) error {
+					return tx.AutoMigrate(&types.User{})
+				},
+				Rollback: func(db *gorm.DB) error { return nil },
+			},
+			// Add the author and message of policy revisions.
+			{
+				ID: "202610171100",
+				Migrate: func(tx *gorm.DB) error {
+					return tx.AutoMigrate(&types.Policy{})
+				},
+				Rollback: func(db *gorm.DB) error { return nil },
+			},
+			// Add the access grants, temporary rules of the policy.
+			{
+				ID: "202610171200",
+				Migrate: func(tx *gorm.DB) error {
+					return tx.AutoMigrate(&types.AccessGrant{})
+				},
+				Rollback: func(db *gorm.DB) error { return nil },
+			},
+			// Add the latest client version set by admins.
+			{
+				ID: "202610171300",
+				Migrate: func(tx *gorm.DB) error {
+					return tx.AutoMigrate(&types.ClientVersion{})
+				},
+				Rollback: func(db *gorm.DB) error { return nil },
+			},
+			// Add the messages broadcast to the clients by admins.
+			{
+				ID: "202610171400",
+				Migrate: func(tx *gorm.DB) error {
+					return tx.AutoMigrate(&types.BroadcastMessage{})
+				},
+				Rollback: func(db *gorm.DB) error { return nil },
+			},
+			// Add the DNS records managed through the API.
+			{
+				ID: "202610171500",
+				Migrate: func(tx *gorm.DB) error {
+					return tx.AutoMigrate(&types.DNSRecord{})
+				},
+				Rollback: func(db *gorm.DB) error { return nil },
+			},
 		},
 	)
 
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"gorm.io/gorm"
+)
+
+// CreateDNSRecord stores a new DNS record, unless one with the same name,
+// type and value has not expired.
+func (hsdb *HSDatabase) CreateDNSRecord(record *types.DNSRecord) error {
+	return hsdb.DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&types.DNSRecord{}).
+			Where("name = ? AND type = ? AND value = ?", record.Name, record.Type, record.Value).
+			Where("expiration IS NULL OR expiration > ?", time.Now()).
+			Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return types.ErrDNSRecordExists
+		}
+
+		return tx.Create(record).Error
+	})
+}
+
+// ListDNSRecords returns the DNS records that have not expired, sorted by
+// name.
+func (hsdb *HSDatabase) ListDNSRecords() ([]types.DNSRecord, error) {
+	var records []types.DNSRecord
+	if err := hsdb.DB.
+		Where("expiration IS NULL OR expiration > ?", time.Now()).
+		Order("name, id").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// DeleteDNSRecord deletes the DNS record with the given ID.
+func (hsdb *HSDatabase) DeleteDNSRecord(id uint64) (*types.DNSRecord, error) {
+	var record types.DNSRecord
+	if err := hsdb.DB.First(&record, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrDNSRecordNotFound
+		}
+
+		return nil, err
+	}
+
+	if err := hsdb.DB.Delete(&record).Error; err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// DeleteExpiredDNSRecords deletes the DNS records that expired before
+// now, and returns them.
+func (hsdb *HSDatabase) DeleteExpiredDNSRecords(now time.Time) ([]types.DNSRecord, error) {
+	var expired []types.DNSRecord
+	if err := hsdb.DB.Where("expiration <= ?", now).Find(&expired).Error; err != nil {
+		return nil, err
+	}
+
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	if err := hsdb.DB.Delete(&expired).Error; err != nil {
+		return nil, err
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSRecords(t *testing.T) {
+	db, err := newTestDB()
+	require.NoError(t, err)
+
+	now := time.Now()
+	later := now.Add(time.Hour)
+	expired := now.Add(-time.Minute)
+
+	ci := &types.DNSRecord{
+		Name:       "ci-42.example.com",
+		Type:       "A",
+		Value:      "100.64.0.7",
+		Author:     types.PolicyAuthorSocket,
+		Expiration: &later,
+	}
+	web := &types.DNSRecord{Name: "web.example.com", Type: "A", Value: "100.64.0.8"}
+	old := &types.DNSRecord{Name: "ci-41.example.com", Type: "A", Value: "100.64.0.7", Expiration: &expired}
+
+	for _, record := range []*types.DNSRecord{web, ci, old} {
+		require.NoError(t, db.CreateDNSRecord(record))
+	}
+
+	err = db.CreateDNSRecord(&types.DNSRecord{Name: "web.example.com", Type: "A", Value: "100.64.0.8"})
+	require.ErrorIs(t, err, types.ErrDNSRecordExists)
+
+	// An expired record does not prevent creating it again.
+	require.NoError(t, db.CreateDNSRecord(&types.DNSRecord{Name: "ci-41.example.com", Type: "A", Value: "100.64.0.7"}))
+
+	records, err := db.ListDNSRecords()
+	require.NoError(t, err)
+
+	var names []string
+	for _, record := range records {
+		names = append(names, record.Name)
+	}
+	assert.Equal(t, []string{"ci-41.example.com", "ci-42.example.com", "web.example.com"}, names)
+
+	deleted, err := db.DeleteExpiredDNSRecords(now)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, old.ID, deleted[0].ID)
+
+	_, err = db.DeleteDNSRecord(web.ID)
+	require.NoError(t, err)
+
+	_, err = db.DeleteDNSRecord(web.ID)
+	require.ErrorIs(t, err, types.ErrDNSRecordNotFound)
+
+	records, err = db.ListDNSRecords()
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+}
@@ -220,7 +220,7 @@ func (s *Suite) TestGetACLFilteredPeers(c *check.C) {
 		Groups: map[string][]string{
 			"group:test": {"admin"},
 		},
-		Hosts:     map[string]netip.Prefix{},
+		Hosts:     policy.Hosts{},
 		TagOwners: map[string][]string{},
 		ACLs: []policy.ACL{
 			{
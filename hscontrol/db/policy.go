@@ -8,11 +8,13 @@ import (
 	"gorm.io/gorm/clause"
 )
 
-// SetPolicy sets the policy in the database.
-func (hsdb *HSDatabase) SetPolicy(policy string) (*types.Policy, error) {
+// SetPolicy sets the policy in the database, as a new revision.
+func (hsdb *HSDatabase) SetPolicy(policy, author, message string) (*types.Policy, error) {
 	// Create a new policy.
 	p := types.Policy{
-		Data: policy,
+		Data:    policy,
+		Author:  author,
+		Message: message,
 	}
 
 	if err := hsdb.DB.Clauses(clause.Returning{}).Create(&p).Error; err != nil {
@@ -41,3 +43,27 @@ func (hsdb *HSDatabase) GetPolicy() (*types.Policy, error) {
 
 	return &p, nil
 }
+
+// ListPolicyRevisions returns all revisions of the policy, the latest first.
+func (hsdb *HSDatabase) ListPolicyRevisions() ([]types.Policy, error) {
+	var revisions []types.Policy
+	if err := hsdb.DB.Order("id DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// GetPolicyRevision returns the revision of the policy with the given ID.
+func (hsdb *HSDatabase) GetPolicyRevision(id uint) (*types.Policy, error) {
+	var p types.Policy
+	if err := hsdb.DB.First(&p, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrPolicyNotFound
+		}
+
+		return nil, err
+	}
+
+	return &p, nil
+}
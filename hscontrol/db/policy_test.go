@@ -0,0 +1,45 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyRevisions(t *testing.T) {
+	db, err := newTestDB()
+	require.NoError(t, err)
+
+	_, err = db.GetPolicy()
+	assert.ErrorIs(t, err, types.ErrPolicyNotFound)
+
+	first, err := db.SetPolicy(`{"acls": []}`, types.PolicyAuthorSocket, "initial policy")
+	require.NoError(t, err)
+
+	second, err := db.SetPolicy(`{"groups": {}}`, "abcdefghij", "")
+	require.NoError(t, err)
+	assert.Greater(t, second.ID, first.ID)
+
+	latest, err := db.GetPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, latest.ID)
+	assert.Equal(t, `{"groups": {}}`, latest.Data)
+	assert.Equal(t, "abcdefghij", latest.Author)
+
+	revisions, err := db.ListPolicyRevisions()
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, second.ID, revisions[0].ID)
+	assert.Equal(t, first.ID, revisions[1].ID)
+	assert.Equal(t, "initial policy", revisions[1].Message)
+	assert.Equal(t, types.PolicyAuthorSocket, revisions[1].Author)
+
+	revision, err := db.GetPolicyRevision(first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, `{"acls": []}`, revision.Data)
+
+	_, err = db.GetPolicyRevision(second.ID + 1)
+	assert.ErrorIs(t, err, types.ErrPolicyNotFound)
+}
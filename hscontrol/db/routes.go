@@ -8,6 +8,7 @@ import (
 
 	"github.com/juanfont/headscale/hscontrol/policy"
 	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/juanfont/headscale/hscontrol/util"
 	"github.com/puzpuzpuz/xsync/v3"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
@@ -123,6 +124,12 @@ func EnableRoute(tx *gorm.DB, id uint64) (*types.StateUpdate, error) {
 		)
 	}
 
+	if util.IsViaPrefix(route.Prefix) {
+		if err := util.ValidateViaPrefix(route.Prefix); err != nil {
+			return nil, err
+		}
+	}
+
 	return enableRoutes(tx, &route.Node, netip.Prefix(route.Prefix))
 }
 
@@ -352,6 +359,19 @@ func SaveNodeRoutes(tx *gorm.DB, node *types.Node) (bool, error) {
 
 	advertisedRoutes := map[netip.Prefix]bool{}
 	for _, prefix := range node.Hostinfo.RoutableIPs {
+		// 4via6 routes must contain a site ID and an IPv4 prefix to be
+		// translated by the subnet router, ignore the ones that do not.
+		if util.IsViaPrefix(prefix) {
+			if err := util.ValidateViaPrefix(prefix); err != nil {
+				log.Warn().
+					Err(err).
+					Str("node", node.Hostname).
+					Msg("ignoring advertised route")
+
+				continue
+			}
+		}
+
 		advertisedRoutes[prefix] = false
 	}
 
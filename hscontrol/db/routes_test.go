@@ -1,6 +1,7 @@
 package db
 
 import (
+	"errors"
 	"net/netip"
 	"os"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/puzpuzpuz/xsync/v3"
 	"gopkg.in/check.v1"
 	"gorm.io/gorm"
+	"tailscale.com/net/tsaddr"
 	"tailscale.com/tailcfg"
 	"tailscale.com/types/ptr"
 )
@@ -230,6 +232,69 @@ func (s *Suite) TestIsUniquePrefix(c *check.C) {
 	c.Assert(len(routes), check.Equals, 0)
 }
 
+func (s *Suite) TestViaRoutes(c *check.C) {
+	user, err := db.CreateUser("test")
+	c.Assert(err, check.IsNil)
+
+	pak, err := db.CreatePreAuthKey(user.Name, false, false, nil, nil)
+	c.Assert(err, check.IsNil)
+
+	// Two sites using the same IPv4 network, and a prefix in the 4via6
+	// range without a site ID.
+	site1 := mp("fd7a:115c:a1e0:b1a:0:1:c0a8:100/120")
+	site2 := mp("fd7a:115c:a1e0:b1a:0:2:c0a8:100/120")
+	invalid := mp("fd7a:115c:a1e0:b1a::/64")
+
+	hostInfo := tailcfg.Hostinfo{
+		RoutableIPs: []netip.Prefix{site1, site2, invalid},
+	}
+	node := types.Node{
+		ID:             0,
+		Hostname:       "test_via_route_node",
+		UserID:         user.ID,
+		RegisterMethod: util.RegisterMethodAuthKey,
+		AuthKeyID:      ptr.To(pak.ID),
+		Hostinfo:       &hostInfo,
+	}
+	trx := db.DB.Save(&node)
+	c.Assert(trx.Error, check.IsNil)
+
+	_, err = db.SaveNodeRoutes(&node)
+	c.Assert(err, check.IsNil)
+
+	advertisedRoutes, err := db.GetAdvertisedRoutes(&node)
+	c.Assert(err, check.IsNil)
+	tsaddr.SortPrefixes(advertisedRoutes)
+	c.Assert(advertisedRoutes, check.DeepEquals, []netip.Prefix{site1, site2})
+
+	routes, err := db.GetNodeRoutes(&node)
+	c.Assert(err, check.IsNil)
+
+	for _, route := range routes {
+		_, err = Write(db.DB, func(tx *gorm.DB) (*types.StateUpdate, error) {
+			return EnableRoute(tx, uint64(route.ID))
+		})
+		c.Assert(err, check.IsNil)
+	}
+
+	enabledRoutes, err := db.GetEnabledRoutes(&node)
+	c.Assert(err, check.IsNil)
+	c.Assert(len(enabledRoutes), check.Equals, 2)
+
+	// A route stored before it was validated cannot be enabled.
+	route := types.Route{
+		NodeID:     node.ID.Uint64(),
+		Prefix:     invalid,
+		Advertised: true,
+	}
+	c.Assert(db.DB.Create(&route).Error, check.IsNil)
+
+	_, err = Write(db.DB, func(tx *gorm.DB) (*types.StateUpdate, error) {
+		return EnableRoute(tx, uint64(route.ID))
+	})
+	c.Assert(errors.Is(err, util.ErrInvalidViaPrefix), check.Equals, true)
+}
+
 func (s *Suite) TestDeleteRoutes(c *check.C) {
 	user, err := db.CreateUser("test")
 	c.Assert(err, check.IsNil)
@@ -0,0 +1,74 @@
+package hscontrol
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/rs/zerolog/log"
+	"tailscale.com/tailcfg"
+)
+
+// updateDNSRecords sends the DNS records that have not expired to the
+// nodes. Map sessions only send what changed, so the nodes only get
+// their new DNS config.
+func (h *Headscale) updateDNSRecords(origin string) error {
+	h.dnsRecordsMu.Lock()
+	defer h.dnsRecordsMu.Unlock()
+
+	records, err := h.db.ListDNSRecords()
+	if err != nil {
+		return fmt.Errorf("loading DNS records from database: %w", err)
+	}
+
+	tailRecords := make([]tailcfg.DNSRecord, len(records))
+	for index, record := range records {
+		tailRecords[index] = record.TailscaleDNSRecord()
+	}
+
+	h.mapper.SetDNSRecords(tailRecords)
+
+	ctx := types.NotifyCtx(context.Background(), origin, "na")
+	h.nodeNotifier.NotifyAll(ctx, types.StateUpdate{
+		Type: types.StateFullUpdate,
+	})
+
+	return nil
+}
+
+// expireDNSRecords deletes the DNS records after they expire, and removes
+// them from the nodes.
+func (h *Headscale) expireDNSRecords(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+
+	for {
+		select {
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		case <-ticker.C:
+			expired, err := h.db.DeleteExpiredDNSRecords(time.Now())
+			if err != nil {
+				log.Error().Err(err).Msg("database error while expiring DNS records")
+				continue
+			}
+
+			if len(expired) == 0 {
+				continue
+			}
+
+			for _, record := range expired {
+				log.Info().
+					Uint64("dns_record", record.ID).
+					Str("name", record.Name).
+					Str("type", record.Type).
+					Msg("DNS record expired")
+			}
+
+			if err := h.updateDNSRecords("dns-record-expired"); err != nil {
+				log.Error().Err(err).Msg("failed to remove expired DNS records from the nodes")
+			}
+		}
+	}
+}
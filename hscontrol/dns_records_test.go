@@ -0,0 +1,34 @@
+package hscontrol
+
+import (
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/mapper"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"gopkg.in/check.v1"
+	"tailscale.com/tailcfg"
+)
+
+func (s *Suite) TestUpdateDNSRecords(c *check.C) {
+	app.mapper = mapper.NewMapper(app.db, app.cfg, &tailcfg.DERPMap{}, app.nodeNotifier)
+
+	updates := make(chan types.StateUpdate, 1)
+	app.nodeNotifier.AddNode(1, updates)
+	defer app.nodeNotifier.RemoveNode(1, updates)
+
+	expired := time.Now().Add(-time.Minute)
+	for _, record := range []*types.DNSRecord{
+		{Name: "ci-42.example.com", Type: "A", Value: "100.64.0.7"},
+		{Name: "ci-41.example.com", Type: "A", Value: "100.64.0.6", Expiration: &expired},
+	} {
+		c.Assert(app.db.CreateDNSRecord(record), check.IsNil)
+	}
+
+	c.Assert(app.updateDNSRecords("test"), check.IsNil)
+	c.Assert(app.mapper.DNSRecords(), check.DeepEquals, []tailcfg.DNSRecord{
+		{Name: "ci-42.example.com", Type: "A", Value: "100.64.0.7"},
+	})
+
+	update := <-updates
+	c.Assert(update.Type, check.Equals, types.StateFullUpdate)
+}
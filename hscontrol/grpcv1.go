@@ -6,13 +6,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"net/netip"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
@@ -21,6 +25,7 @@ import (
 
 	v1 "github.com/juanfont/headscale/gen/go/headscale/v1"
 	"github.com/juanfont/headscale/hscontrol/db"
+	"github.com/juanfont/headscale/hscontrol/mapper"
 	"github.com/juanfont/headscale/hscontrol/policy"
 	"github.com/juanfont/headscale/hscontrol/types"
 	"github.com/juanfont/headscale/hscontrol/util"
@@ -75,6 +80,14 @@ func (api headscaleV1APIServer) RenameUser(
 		return nil, err
 	}
 
+	// The policy refers to users by name.
+	api.h.nodeNotifier.NodesChanged()
+
+	ctx = types.NotifyCtx(ctx, "cli-renameuser", "na")
+	api.h.nodeNotifier.NotifyAll(ctx, types.StateUpdate{
+		Type: types.StateFullUpdate,
+	})
+
 	return &v1.RenameUserResponse{User: user.Proto()}, nil
 }
 
@@ -221,6 +234,8 @@ func (api headscaleV1APIServer) RegisterNode(
 		return nil, err
 	}
 
+	api.h.nodeNotifier.NodesChanged()
+
 	return &v1.RegisterNodeResponse{Node: node.Proto()}, nil
 }
 
@@ -267,6 +282,8 @@ func (api headscaleV1APIServer) SetTags(
 		}, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	api.h.nodeNotifier.NodesChanged()
+
 	ctx = types.NotifyCtx(ctx, "cli-settags", node.Hostname)
 	api.h.nodeNotifier.NotifyWithIgnore(ctx, types.StateUpdate{
 		Type:        types.StatePeerChanged,
@@ -312,6 +329,8 @@ func (api headscaleV1APIServer) DeleteNode(
 		return nil, err
 	}
 
+	api.h.nodeNotifier.NodesChanged()
+
 	ctx = types.NotifyCtx(ctx, "cli-deletenode", node.Hostname)
 	api.h.nodeNotifier.NotifyAll(ctx, types.StateUpdate{
 		Type:    types.StatePeerRemoved,
@@ -347,6 +366,8 @@ func (api headscaleV1APIServer) ExpireNode(
 		return nil, err
 	}
 
+	api.h.nodeNotifier.NodesChanged()
+
 	ctx = types.NotifyCtx(ctx, "cli-expirenode-self", node.Hostname)
 	api.h.nodeNotifier.NotifyByNodeID(
 		ctx,
@@ -357,7 +378,7 @@ func (api headscaleV1APIServer) ExpireNode(
 		node.ID)
 
 	ctx = types.NotifyCtx(ctx, "cli-expirenode-peers", node.Hostname)
-	api.h.nodeNotifier.NotifyWithIgnore(ctx, types.StateUpdateExpire(node.ID, now), node.ID)
+	api.h.nodeNotifier.NotifyWithIgnore(ctx, api.h.expiryUpdate(types.StateUpdateExpire(node.ID, now)), node.ID)
 
 	log.Trace().
 		Str("node", node.Hostname).
@@ -387,6 +408,8 @@ func (api headscaleV1APIServer) RenameNode(
 		return nil, err
 	}
 
+	api.h.nodeNotifier.NodesChanged()
+
 	ctx = types.NotifyCtx(ctx, "cli-renamenode", node.Hostname)
 	api.h.nodeNotifier.NotifyWithIgnore(ctx, types.StateUpdate{
 		Type:        types.StatePeerChanged,
@@ -450,7 +473,7 @@ func (api headscaleV1APIServer) ListNodes(
 			resp.Online = true
 		}
 
-		validTags, invalidTags := api.h.ACLPolicy.TagsOfNode(
+		validTags, invalidTags := api.h.ACLPolicy().TagsOfNode(
 			node,
 		)
 		resp.InvalidTags = invalidTags
@@ -475,6 +498,8 @@ func (api headscaleV1APIServer) MoveNode(
 		return nil, err
 	}
 
+	api.h.nodeNotifier.NodesChanged()
+
 	return &v1.MoveNodeResponse{Node: node.Proto()}, nil
 }
 
@@ -493,9 +518,109 @@ func (api headscaleV1APIServer) BackfillNodeIPs(
 		return nil, err
 	}
 
+	api.h.nodeNotifier.NodesChanged()
+
 	return &v1.BackfillNodeIPsResponse{Changes: changes}, nil
 }
 
+func (api headscaleV1APIServer) ApproveSSHCheck(
+	ctx context.Context,
+	request *v1.ApproveSSHCheckRequest,
+) (*v1.ApproveSSHCheckResponse, error) {
+	check, err := api.h.sshChecks.approve(request.GetCheckId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	src, err := api.h.db.GetNodeByID(check.SrcNodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	dst, err := api.h.db.GetNodeByID(check.DstNodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("check", check.ID).
+		Str("src", src.Hostname).
+		Str("dst", dst.Hostname).
+		Msg("SSH check approved")
+
+	return &v1.ApproveSSHCheckResponse{
+		SrcNode:   src.Proto(),
+		DstNode:   dst.Proto(),
+		SshUser:   check.SSHUser,
+		LocalUser: check.LocalUser,
+	}, nil
+}
+
+func (api headscaleV1APIServer) PingNode(
+	ctx context.Context,
+	request *v1.PingNodeRequest,
+) (*v1.PingNodeResponse, error) {
+	node, err := api.h.db.GetNodeByID(types.NodeID(request.GetNodeId()))
+	if err != nil {
+		return nil, err
+	}
+
+	pingType := request.GetType()
+	if pingType == "" {
+		pingType = PingTypeC2N
+	}
+
+	var target netip.Addr
+	if request.GetTargetNodeId() != 0 {
+		targetNode, err := api.h.db.GetNodeByID(types.NodeID(request.GetTargetNodeId()))
+		if err != nil {
+			return nil, err
+		}
+
+		if ips := targetNode.IPs(); len(ips) > 0 {
+			target = ips[0]
+		}
+	}
+
+	resp, err := api.h.pingNode(ctx, node, pingType, target)
+	switch {
+	case errors.Is(err, ErrInvalidPingType), errors.Is(err, ErrPingTarget):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrNodeNotConnected):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrPingTimeout):
+		return nil, status.Error(codes.DeadlineExceeded, err.Error())
+	case err != nil:
+		return nil, err
+	}
+
+	response := &v1.PingNodeResponse{
+		Type:           string(resp.Type),
+		LatencySeconds: resp.LatencySeconds,
+		Endpoint:       resp.Endpoint,
+		DerpRegion:     resp.DERPRegionCode,
+		Error:          resp.Err,
+	}
+
+	if target.IsValid() {
+		response.TargetIp = target.String()
+	}
+
+	switch {
+	case pingType == PingTypeC2N:
+		response.Path = "control"
+	case resp.Endpoint != "":
+		response.Path = "direct"
+	case resp.DERPRegionID != 0:
+		response.Path = "derp"
+		if response.DerpRegion == "" {
+			response.DerpRegion = strconv.Itoa(resp.DERPRegionID)
+		}
+	}
+
+	return response, nil
+}
+
 func (api headscaleV1APIServer) GetRoutes(
 	ctx context.Context,
 	request *v1.GetRoutesRequest,
@@ -524,6 +649,8 @@ func (api headscaleV1APIServer) EnableRoute(
 	}
 
 	if update != nil {
+		api.h.nodeNotifier.NodesChanged()
+
 		ctx := types.NotifyCtx(ctx, "cli-enableroute", "unknown")
 		api.h.nodeNotifier.NotifyAll(
 			ctx, *update)
@@ -544,6 +671,8 @@ func (api headscaleV1APIServer) DisableRoute(
 	}
 
 	if update != nil {
+		api.h.nodeNotifier.NodesChanged()
+
 		ctx := types.NotifyCtx(ctx, "cli-disableroute", "unknown")
 		api.h.nodeNotifier.NotifyAll(ctx, types.StateUpdate{
 			Type:        types.StatePeerChanged,
@@ -586,6 +715,8 @@ func (api headscaleV1APIServer) DeleteRoute(
 	}
 
 	if update != nil {
+		api.h.nodeNotifier.NodesChanged()
+
 		ctx := types.NotifyCtx(ctx, "cli-deleteroute", "unknown")
 		api.h.nodeNotifier.NotifyAll(ctx, types.StateUpdate{
 			Type:        types.StatePeerChanged,
@@ -691,6 +822,7 @@ func (api headscaleV1APIServer) GetPolicy(
 		return &v1.GetPolicyResponse{
 			Policy:    p.Data,
 			UpdatedAt: timestamppb.New(p.UpdatedAt),
+			Revision:  uint64(p.ID),
 		}, nil
 	case types.PolicyModeFile:
 		// Read the file and return the contents as-is.
@@ -708,68 +840,716 @@ func (api headscaleV1APIServer) GetPolicy(
 		}
 
 		return &v1.GetPolicyResponse{Policy: string(b)}, nil
+	case types.PolicyModeURL:
+		// Return the last policy fetched from the URL as-is.
+		return &v1.GetPolicyResponse{Policy: string(api.h.policyLoader.Data())}, nil
 	}
 
 	return nil, fmt.Errorf("no supported policy mode found in configuration, policy.mode: %q", api.h.cfg.Policy.Mode)
 }
 
 func (api headscaleV1APIServer) SetPolicy(
-	_ context.Context,
+	ctx context.Context,
 	request *v1.SetPolicyRequest,
 ) (*v1.SetPolicyResponse, error) {
+	// A dry run does not store the policy, so it is also allowed
+	// when the policy is read from a file.
+	if request.GetDryRun() {
+		return api.dryRunPolicy(request.GetPolicy())
+	}
+
 	if api.h.cfg.Policy.Mode != types.PolicyModeDB {
 		return nil, types.ErrPolicyUpdateIsDisabled
 	}
 
-	p := request.GetPolicy()
+	updated, err := api.applyPolicy(ctx, request.GetPolicy(), request.GetMessage())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &v1.SetPolicyResponse{
+		Policy:    updated.Data,
+		UpdatedAt: timestamppb.New(updated.UpdatedAt),
+		Revision:  uint64(updated.ID),
+	}
 
+	return response, nil
+}
+
+// dryRunPolicy validates the policy and reports how it would change the
+// peers and packet filter of every node, without applying it.
+func (api headscaleV1APIServer) dryRunPolicy(p string) (*v1.SetPolicyResponse, error) {
+	pol, err := api.validatePolicy(p)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := api.h.db.ListNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	// Access grants are kept when the policy changes, they are left out
+	// of the comparison.
+	impacts, err := policy.DiffPolicies(api.h.ACLPolicy().WithAccessGrants(nil), pol, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &v1.SetPolicyResponse{
+		Policy: p,
+	}
+
+	for _, impact := range impacts {
+		response.Impact = append(response.Impact, &v1.NodePolicyImpact{
+			NodeId:              impact.Node.ID.Uint64(),
+			Node:                impact.Node.GivenName,
+			PeersGained:         impactPeerNames(impact.PeersGained),
+			PeersLost:           impactPeerNames(impact.PeersLost),
+			DestinationsAdded:   impact.DestinationsAdded,
+			DestinationsRemoved: impact.DestinationsRemoved,
+		})
+	}
+
+	return response, nil
+}
+
+func impactPeerNames(nodes types.Nodes) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.GivenName)
+	}
+
+	return names
+}
+
+// applyPolicy validates the policy, stores it as a new revision and
+// sends the change to all nodes.
+func (api headscaleV1APIServer) applyPolicy(
+	ctx context.Context,
+	p string,
+	message string,
+) (*types.Policy, error) {
+	api.h.policyMu.Lock()
+	defer api.h.policyMu.Unlock()
+
+	pol, err := api.validatePolicy(p)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := api.h.db.SetPolicy(p, policyAuthor(ctx), message)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := api.h.setACLPolicy(pol); err != nil {
+		return nil, err
+	}
+	api.h.policyLoaded([]byte(p))
+
+	notifyCtx := types.NotifyCtx(context.Background(), "acl-update", "na")
+	api.h.nodeNotifier.NotifyAll(notifyCtx, types.StateUpdate{
+		Type: types.StateFullUpdate,
+	})
+
+	return updated, nil
+}
+
+// validatePolicy parses the policy and validates it like a policy loaded
+// from a file or URL.
+func (api headscaleV1APIServer) validatePolicy(p string) (*policy.ACLPolicy, error) {
 	pol, err := policy.LoadACLPolicyFromBytes([]byte(p))
 	if err != nil {
-		return nil, fmt.Errorf("loading ACL policy file: %w", err)
+		return nil, status.Errorf(codes.InvalidArgument, "loading ACL policy file: %s", err)
+	}
+
+	err = api.h.validateACLPolicy(pol)
+	if errors.Is(err, errInvalidPolicy) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return pol, err
+}
+
+// policyAuthor returns the prefix of the API key used for the request,
+// or "cli-socket" for requests over the local socket without one.
+// Requests with an API key have been authenticated by the gRPC
+// interceptor or, for the HTTP API, by the HTTP middleware.
+func policyAuthor(ctx context.Context) string {
+	meta, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return types.PolicyAuthorSocket
+	}
+
+	for _, auth := range meta.Get("authorization") {
+		if prefix, _, ok := strings.Cut(strings.TrimPrefix(auth, AuthPrefix), "."); ok {
+			return prefix
+		}
+	}
+
+	return types.PolicyAuthorSocket
+}
+
+func (api headscaleV1APIServer) ListPolicyRevisions(
+	_ context.Context,
+	_ *v1.ListPolicyRevisionsRequest,
+) (*v1.ListPolicyRevisionsResponse, error) {
+	revisions, err := api.h.db.ListPolicyRevisions()
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*v1.PolicyRevision, len(revisions))
+	for index, revision := range revisions {
+		response[index] = policyRevisionToProto(&revision)
+		response[index].Policy = ""
+	}
+
+	return &v1.ListPolicyRevisionsResponse{Revisions: response}, nil
+}
+
+func (api headscaleV1APIServer) GetPolicyRevision(
+	_ context.Context,
+	request *v1.GetPolicyRevisionRequest,
+) (*v1.GetPolicyRevisionResponse, error) {
+	revision, err := api.h.db.GetPolicyRevision(uint(request.GetId()))
+	if err != nil {
+		if errors.Is(err, types.ErrPolicyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "policy revision %d not found", request.GetId())
+		}
+
+		return nil, err
+	}
+
+	return &v1.GetPolicyRevisionResponse{Revision: policyRevisionToProto(revision)}, nil
+}
+
+func (api headscaleV1APIServer) RollbackPolicy(
+	ctx context.Context,
+	request *v1.RollbackPolicyRequest,
+) (*v1.RollbackPolicyResponse, error) {
+	if api.h.cfg.Policy.Mode != types.PolicyModeDB {
+		return nil, types.ErrPolicyUpdateIsDisabled
+	}
+
+	revision, err := api.h.db.GetPolicyRevision(uint(request.GetId()))
+	if err != nil {
+		if errors.Is(err, types.ErrPolicyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "policy revision %d not found", request.GetId())
+		}
+
+		return nil, err
+	}
+
+	message := request.GetMessage()
+	if message == "" {
+		message = fmt.Sprintf("Rollback to revision %d", revision.ID)
+	}
+
+	updated, err := api.applyPolicy(ctx, revision.Data, message)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.RollbackPolicyResponse{Revision: policyRevisionToProto(updated)}, nil
+}
+
+func policyRevisionToProto(p *types.Policy) *v1.PolicyRevision {
+	return &v1.PolicyRevision{
+		Id:        uint64(p.ID),
+		Author:    p.Author,
+		Message:   p.Message,
+		CreatedAt: timestamppb.New(p.CreatedAt),
+		Policy:    p.Data,
+	}
+}
+
+func (api headscaleV1APIServer) CheckAccess(
+	_ context.Context,
+	request *v1.CheckAccessRequest,
+) (*v1.CheckAccessResponse, error) {
+	if request.GetPort() > math.MaxUint16 {
+		return nil, status.Errorf(codes.InvalidArgument, "port %d is out of range", request.GetPort())
+	}
+
+	// The access is checked with the policy compiled for the map
+	// responses, so the answer is the one the nodes give.
+	compiled, err := api.h.mapper.CompilePolicy(api.h.ACLPolicy())
+	if err != nil {
+		return nil, fmt.Errorf("compiling policy: %w", err)
 	}
 
-	// Validate and reject configuration that would error when applied
-	// when creating a map response. This requires nodes, so there is still
-	// a scenario where they might be allowed if the server has no nodes
-	// yet, but it should help for the general case and for hot reloading
-	// configurations.
 	nodes, err := api.h.db.ListNodes()
 	if err != nil {
-		return nil, fmt.Errorf("loading nodes from database to validate policy: %w", err)
+		return nil, fmt.Errorf("loading nodes from database: %w", err)
 	}
 
-	_, err = pol.CompileFilterRules(nodes)
+	srcs, err := api.accessAddrs(nodes, request.GetSource(), true)
 	if err != nil {
-		return nil, fmt.Errorf("verifying policy rules: %w", err)
+		return nil, status.Errorf(codes.InvalidArgument, "resolving source: %s", err)
 	}
 
-	if len(nodes) > 0 {
-		_, err = pol.CompileSSHPolicy(nodes[0], nodes)
+	dsts, err := api.accessAddrs(nodes, request.GetDestination(), false)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "resolving destination: %s", err)
+	}
+
+	response := &v1.CheckAccessResponse{
+		Allowed: true,
+	}
+
+	for _, src := range srcs {
+		checked := false
+		for _, dst := range dsts {
+			if src.Is4() != dst.Is4() {
+				continue
+			}
+
+			check, err := compiled.CheckAccess(
+				src,
+				dst,
+				uint16(request.GetPort()),
+				request.GetProtocol(),
+			)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+
+			response.Checks = append(response.Checks, accessCheckToProto(check))
+			response.Allowed = response.Allowed && check.Allowed
+			checked = true
+		}
+
+		// A source without an address in the family of the destination
+		// can never reach it.
+		if !checked {
+			response.Allowed = false
+		}
+	}
+
+	return response, nil
+}
+
+// accessAddrs resolves the source or destination of CheckAccess, a node
+// ID, node name, user or IP address, to the addresses it stands for.
+func (api headscaleV1APIServer) accessAddrs(
+	nodes types.Nodes,
+	target string,
+	allowUser bool,
+) ([]netip.Addr, error) {
+	if addr, err := netip.ParseAddr(target); err == nil {
+		return []netip.Addr{addr}, nil
+	}
+
+	if id, err := strconv.ParseUint(target, util.Base10, 64); err == nil {
+		for _, node := range nodes {
+			if node.ID == types.NodeID(id) {
+				return node.IPs(), nil
+			}
+		}
+	}
+
+	for _, node := range nodes {
+		if node.GivenName == target || node.Hostname == target {
+			return node.IPs(), nil
+		}
+	}
+
+	if allowUser {
+		var addrs []netip.Addr
+		found := false
+		for _, node := range nodes {
+			if node.User.Name == target {
+				addrs = append(addrs, node.IPs()...)
+				found = true
+			}
+		}
+
+		if found {
+			return addrs, nil
+		}
+
+		return nil, fmt.Errorf("no node, user or IP address found for %q", target)
+	}
+
+	return nil, fmt.Errorf("no node or IP address found for %q", target)
+}
+
+func accessCheckToProto(check *policy.AccessCheck) *v1.AccessCheck {
+	ret := &v1.AccessCheck{
+		Source:      check.Source.String(),
+		Destination: check.Destination.String(),
+		Node:        check.Node.GivenName,
+		Allowed:     check.Allowed,
+	}
+
+	for _, match := range check.Matches {
+		ret.Matches = append(ret.Matches, &v1.AccessMatch{
+			Section:      match.Section,
+			Index:        int32(match.Index),
+			Sources:      match.Sources,
+			Destinations: match.Destinations,
+		})
+	}
+
+	return ret
+}
+
+// GetAccessGraph returns who can reach whom with the current policy.
+func (api headscaleV1APIServer) GetAccessGraph(
+	_ context.Context,
+	_ *v1.GetAccessGraphRequest,
+) (*v1.GetAccessGraphResponse, error) {
+	nodes, err := api.h.db.ListNodes()
+	if err != nil {
+		return nil, fmt.Errorf("loading nodes from database: %w", err)
+	}
+
+	graph, err := policy.BuildAccessGraph(api.h.ACLPolicy(), nodes)
+	if err != nil {
+		return nil, fmt.Errorf("building access graph: %w", err)
+	}
+
+	response := &v1.GetAccessGraphResponse{}
+	for _, vertex := range graph.Vertices {
+		response.Vertices = append(response.Vertices, &v1.AccessGraphVertex{
+			Id:        vertex.ID,
+			Kind:      vertex.Kind,
+			Name:      vertex.Name,
+			User:      vertex.User,
+			Addresses: vertex.Addresses,
+			Routers:   vertex.Routers,
+		})
+	}
+
+	for _, edge := range graph.Edges {
+		response.Edges = append(response.Edges, &v1.AccessGraphEdge{
+			From:      edge.From,
+			To:        edge.To,
+			Ports:     edge.Ports,
+			Protocols: edge.Protocols,
+		})
+	}
+
+	return response, nil
+}
+
+func (api headscaleV1APIServer) CreateAccessGrant(
+	ctx context.Context,
+	request *v1.CreateAccessGrantRequest,
+) (*v1.CreateAccessGrantResponse, error) {
+	if api.h.ACLPolicy() == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no policy is loaded, all traffic is allowed")
+	}
+
+	if request.GetExpiration() == nil || !request.GetExpiration().AsTime().After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "expiration must be in the future")
+	}
+
+	grant := types.AccessGrant{
+		Sources:      request.GetSources(),
+		Destinations: request.GetDestinations(),
+		Ports:        request.GetPorts(),
+		Protocol:     request.GetProtocol(),
+		Reason:       request.GetReason(),
+		Author:       policyAuthor(ctx),
+		Expiration:   request.GetExpiration().AsTime(),
+	}
+
+	nodes, err := api.h.db.ListNodes()
+	if err != nil {
+		return nil, fmt.Errorf("loading nodes from database: %w", err)
+	}
+
+	if err := api.h.ACLPolicy().ValidateAccessGrant(grant, nodes); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := api.h.db.CreateAccessGrant(&grant); err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Uint64("access_grant", grant.ID).
+		Strs("src", grant.Sources).
+		Strs("dst", grant.Destinations).
+		Str("ports", grant.Ports).
+		Time("expiration", grant.Expiration).
+		Str("author", grant.Author).
+		Str("reason", grant.Reason).
+		Msg("Access grant created")
+
+	if err := api.h.updateAccessGrants("access-grant-created", []types.AccessGrant{grant}); err != nil {
+		return nil, err
+	}
+
+	return &v1.CreateAccessGrantResponse{AccessGrant: grant.Proto()}, nil
+}
+
+func (api headscaleV1APIServer) ListAccessGrants(
+	_ context.Context,
+	_ *v1.ListAccessGrantsRequest,
+) (*v1.ListAccessGrantsResponse, error) {
+	grants, err := api.h.db.ListAccessGrants()
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*v1.AccessGrant, len(grants))
+	for index, grant := range grants {
+		response[index] = grant.Proto()
+	}
+
+	return &v1.ListAccessGrantsResponse{AccessGrants: response}, nil
+}
+
+func (api headscaleV1APIServer) RevokeAccessGrant(
+	_ context.Context,
+	request *v1.RevokeAccessGrantRequest,
+) (*v1.RevokeAccessGrantResponse, error) {
+	grant, err := api.h.db.DeleteAccessGrant(request.GetId())
+	if err != nil {
+		if errors.Is(err, types.ErrAccessGrantNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		return nil, err
+	}
+
+	log.Info().
+		Uint64("access_grant", grant.ID).
+		Msg("Access grant revoked")
+
+	if err := api.h.updateAccessGrants("access-grant-revoked", []types.AccessGrant{*grant}); err != nil {
+		return nil, err
+	}
+
+	return &v1.RevokeAccessGrantResponse{}, nil
+}
+
+// LintPolicy reports the problems of a policy, or of the current policy,
+// with the current users and nodes.
+func (api headscaleV1APIServer) LintPolicy(
+	_ context.Context,
+	request *v1.LintPolicyRequest,
+) (*v1.LintPolicyResponse, error) {
+	pol := api.h.ACLPolicy()
+	if request.GetPolicy() != "" {
+		var err error
+		pol, err = policy.LoadACLPolicyFromBytes([]byte(request.GetPolicy()))
 		if err != nil {
-			return nil, fmt.Errorf("verifying SSH rules: %w", err)
+			return nil, status.Errorf(codes.InvalidArgument, "loading ACL policy: %s", err)
+		}
+	}
+
+	if pol == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no policy is loaded")
+	}
+
+	users, err := api.h.db.ListUsers()
+	if err != nil {
+		return nil, fmt.Errorf("loading users from database: %w", err)
+	}
+
+	nodes, err := api.h.db.ListNodes()
+	if err != nil {
+		return nil, fmt.Errorf("loading nodes from database: %w", err)
+	}
+
+	response := &v1.LintPolicyResponse{}
+	for _, finding := range pol.Lint(users, nodes) {
+		response.Findings = append(response.Findings, &v1.PolicyFinding{
+			Check:   finding.Check,
+			Section: finding.Section,
+			Index:   int32(finding.Index),
+			Name:    finding.Name,
+			Message: finding.Message,
+		})
+	}
+
+	return response, nil
+}
+
+// SetClientVersion sets the latest client version, taking precedence over
+// the configured file or URL. An empty version unsets it.
+func (api headscaleV1APIServer) SetClientVersion(
+	ctx context.Context,
+	request *v1.SetClientVersionRequest,
+) (*v1.SetClientVersionResponse, error) {
+	if request.GetVersion() != "" {
+		if err := validateClientVersion(request.GetVersion()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
 	}
 
-	updated, err := api.h.db.SetPolicy(p)
+	clientVersion, err := api.h.db.SetClientVersion(request.GetVersion(), policyAuthor(ctx))
 	if err != nil {
 		return nil, err
 	}
 
-	api.h.ACLPolicy = pol
+	log.Info().
+		Str("version", clientVersion.Version).
+		Str("author", clientVersion.Author).
+		Msg("Client version set")
 
-	ctx := types.NotifyCtx(context.Background(), "acl-update", "na")
-	api.h.nodeNotifier.NotifyAll(ctx, types.StateUpdate{
-		Type: types.StateFullUpdate,
+	if err := api.h.updateClientVersion(ctx, false); err != nil {
+		return nil, err
+	}
+
+	return &v1.SetClientVersionResponse{
+		LatestVersion: api.h.mapper.LatestClientVersion(),
+	}, nil
+}
+
+// OutdatedClients lists the nodes running a version of Tailscale older
+// than the latest client version.
+func (api headscaleV1APIServer) OutdatedClients(
+	_ context.Context,
+	_ *v1.OutdatedClientsRequest,
+) (*v1.OutdatedClientsResponse, error) {
+	latest := api.h.mapper.LatestClientVersion()
+	if latest == "" {
+		return nil, status.Error(codes.FailedPrecondition, "the latest client version is not known")
+	}
+
+	nodes, err := api.h.db.ListNodes()
+	if err != nil {
+		return nil, fmt.Errorf("loading nodes from database: %w", err)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
 	})
 
-	response := &v1.SetPolicyResponse{
-		Policy:    updated.Data,
-		UpdatedAt: timestamppb.New(updated.UpdatedAt),
+	isLikelyConnected := api.h.nodeNotifier.LikelyConnectedMap()
+	response := &v1.OutdatedClientsResponse{LatestVersion: latest}
+	for _, node := range nodes {
+		clientVersion := mapper.ClientVersion(node, latest, api.h.cfg.ClientVersion)
+		if clientVersion == nil || clientVersion.RunningLatest {
+			continue
+		}
+
+		resp := node.Proto()
+		if val, ok := isLikelyConnected.Load(node.ID); ok && val {
+			resp.Online = true
+		}
+
+		response.Clients = append(response.Clients, &v1.OutdatedClient{
+			Node:    resp,
+			Version: node.Hostinfo.IPNVersion,
+		})
 	}
 
 	return response, nil
 }
 
+// BroadcastMessage shows a message in the health UI of every client.
+func (api headscaleV1APIServer) BroadcastMessage(
+	ctx context.Context,
+	request *v1.BroadcastMessageRequest,
+) (*v1.BroadcastMessageResponse, error) {
+	author := policyAuthor(ctx)
+	if err := api.h.broadcastMessage(request.GetMessage(), author); err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("message", request.GetMessage()).
+		Str("author", author).
+		Msg("Message broadcast")
+
+	return &v1.BroadcastMessageResponse{}, nil
+}
+
+func (api headscaleV1APIServer) CreateDNSRecord(
+	ctx context.Context,
+	request *v1.CreateDNSRecordRequest,
+) (*v1.CreateDNSRecordResponse, error) {
+	record := types.DNSRecord{
+		Name:   request.GetName(),
+		Type:   request.GetType(),
+		Value:  request.GetValue(),
+		Author: policyAuthor(ctx),
+	}
+
+	if request.GetExpiration() != nil {
+		expiration := request.GetExpiration().AsTime()
+		if !expiration.After(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "expiration must be in the future")
+		}
+		record.Expiration = &expiration
+	}
+
+	if err := record.Normalize(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := api.h.db.CreateDNSRecord(&record); err != nil {
+		if errors.Is(err, types.ErrDNSRecordExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+
+		return nil, err
+	}
+
+	log.Info().
+		Uint64("dns_record", record.ID).
+		Str("name", record.Name).
+		Str("type", record.Type).
+		Str("value", record.Value).
+		Str("author", record.Author).
+		Msg("DNS record created")
+
+	if err := api.h.updateDNSRecords("dns-record-created"); err != nil {
+		return nil, err
+	}
+
+	return &v1.CreateDNSRecordResponse{DnsRecord: record.Proto()}, nil
+}
+
+func (api headscaleV1APIServer) ListDNSRecords(
+	_ context.Context,
+	_ *v1.ListDNSRecordsRequest,
+) (*v1.ListDNSRecordsResponse, error) {
+	records, err := api.h.db.ListDNSRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*v1.DNSRecord, len(records))
+	for index, record := range records {
+		response[index] = record.Proto()
+	}
+
+	return &v1.ListDNSRecordsResponse{DnsRecords: response}, nil
+}
+
+func (api headscaleV1APIServer) DeleteDNSRecord(
+	_ context.Context,
+	request *v1.DeleteDNSRecordRequest,
+) (*v1.DeleteDNSRecordResponse, error) {
+	record, err := api.h.db.DeleteDNSRecord(request.GetId())
+	if err != nil {
+		if errors.Is(err, types.ErrDNSRecordNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		return nil, err
+	}
+
+	log.Info().
+		Uint64("dns_record", record.ID).
+		Str("name", record.Name).
+		Msg("DNS record deleted")
+
+	if err := api.h.updateDNSRecords("dns-record-deleted"); err != nil {
+		return nil, err
+	}
+
+	return &v1.DeleteDNSRecordResponse{}, nil
+}
+
 // The following service calls are for testing and debugging
 func (api headscaleV1APIServer) DebugCreateNode(
 	ctx context.Context,
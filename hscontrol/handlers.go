@@ -171,11 +171,13 @@ func registerWebHTML(key string) *elem.Element {
 
 type AuthProviderWeb struct {
 	serverURL string
+	sshChecks *sshChecks
 }
 
-func NewAuthProviderWeb(serverURL string) *AuthProviderWeb {
+func NewAuthProviderWeb(serverURL string, sshChecks *sshChecks) *AuthProviderWeb {
 	return &AuthProviderWeb{
 		serverURL: serverURL,
+		sshChecks: sshChecks,
 	}
 }
 
@@ -186,6 +188,34 @@ func (a *AuthProviderWeb) AuthURL(mKey key.MachinePublic) string {
 		mKey.String())
 }
 
+func (a *AuthProviderWeb) SSHCheckURL(checkID string) string {
+	return sshCheckURL(a.serverURL, checkID)
+}
+
+// SSHCheckHandler shows the command approving an SSH check, as the
+// user is authenticated by running the CLI on the server.
+// Listens in /ssh/check/:check_id.
+func (a *AuthProviderWeb) SSHCheckHandler(
+	writer http.ResponseWriter,
+	req *http.Request,
+) {
+	check, err := a.sshChecks.get(mux.Vars(req)["check_id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusNotFound)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	writer.WriteHeader(http.StatusOK)
+	if _, err := writer.Write([]byte(templates.SSHCheckWeb(check.ID).Render())); err != nil {
+		log.Error().
+			Caller().
+			Err(err).
+			Msg("Failed to write response")
+	}
+}
+
 // RegisterWebAPI shows a simple message in the browser to point to the CLI
 // Listens in /register/:nkey.
 //
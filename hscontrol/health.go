@@ -0,0 +1,102 @@
+package hscontrol
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/rs/zerolog/log"
+)
+
+// loadBroadcastMessage shows the last message broadcast by the admins to
+// the nodes.
+func (h *Headscale) loadBroadcastMessage() error {
+	message, err := h.db.GetBroadcastMessage()
+	if err != nil {
+		if errors.Is(err, types.ErrBroadcastMessageNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	h.mapper.SetBroadcastMessage(message.Message)
+
+	return nil
+}
+
+// broadcastMessage shows the message in the health UI of every node, an
+// empty message clears it.
+func (h *Headscale) broadcastMessage(message, author string) error {
+	if _, err := h.db.SetBroadcastMessage(message, author); err != nil {
+		return err
+	}
+
+	h.mapper.SetBroadcastMessage(message)
+
+	ctx := types.NotifyCtx(context.Background(), "broadcast-message", "na")
+	h.nodeNotifier.NotifyAll(ctx, types.StateUpdate{
+		Type: types.StateFullUpdate,
+	})
+
+	return nil
+}
+
+// keysEnteringExpiryWarning returns the nodes whose key started to expire
+// within the warning between lastCheck and now.
+func keysEnteringExpiryWarning(
+	nodes types.Nodes,
+	warning time.Duration,
+	lastCheck, now time.Time,
+) []types.NodeID {
+	var ids []types.NodeID
+
+	for _, node := range nodes {
+		if node.Expiry == nil || node.Expiry.IsZero() {
+			continue
+		}
+
+		warnFrom := node.Expiry.Add(-warning)
+		if warnFrom.After(lastCheck) && !warnFrom.After(now) {
+			ids = append(ids, node.ID)
+		}
+	}
+
+	return ids
+}
+
+// warnExpiringKeys sends an update to the nodes whose key is about to
+// expire, so the warning shows up in their client. Nodes connecting
+// after that get the warning with their first map.
+func (h *Headscale) warnExpiringKeys(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+
+	lastCheck := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		case <-ticker.C:
+			now := time.Now()
+
+			nodes, err := h.db.ListNodes()
+			if err != nil {
+				log.Error().Err(err).Msg("database error while checking expiring keys")
+				continue
+			}
+
+			for _, id := range keysEnteringExpiryWarning(nodes, h.cfg.Health.KeyExpiryWarning, lastCheck, now) {
+				ctx := types.NotifyCtx(context.Background(), "key-expiry-warning", "na")
+				h.nodeNotifier.NotifyByNodeID(ctx, types.StateUpdate{
+					Type:        types.StateSelfUpdate,
+					ChangeNodes: []types.NodeID{id},
+				}, id)
+			}
+
+			lastCheck = now
+		}
+	}
+}
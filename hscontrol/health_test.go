@@ -0,0 +1,58 @@
+package hscontrol
+
+import (
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/mapper"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"gopkg.in/check.v1"
+	"tailscale.com/tailcfg"
+)
+
+func (s *Suite) TestKeysEnteringExpiryWarning(c *check.C) {
+	now := time.Date(2024, time.October, 1, 12, 0, 0, 0, time.UTC)
+	lastCheck := now.Add(-5 * time.Second)
+	warning := 7 * 24 * time.Hour
+
+	expiry := func(d time.Duration) *time.Time {
+		t := now.Add(warning + d)
+
+		return &t
+	}
+
+	nodes := types.Nodes{
+		{ID: 1},
+		{ID: 2, Expiry: &time.Time{}},
+		// Entered the warning before the last check.
+		{ID: 3, Expiry: expiry(-time.Minute)},
+		{ID: 4, Expiry: expiry(-2 * time.Second)},
+		{ID: 5, Expiry: expiry(0)},
+		// Enters the warning after now.
+		{ID: 6, Expiry: expiry(time.Second)},
+	}
+
+	c.Assert(
+		keysEnteringExpiryWarning(nodes, warning, lastCheck, now),
+		check.DeepEquals,
+		[]types.NodeID{4, 5},
+	)
+}
+
+func (s *Suite) TestBroadcastMessage(c *check.C) {
+	app.mapper = mapper.NewMapper(app.db, app.cfg, &tailcfg.DERPMap{}, app.nodeNotifier)
+
+	updates := make(chan types.StateUpdate, 1)
+	app.nodeNotifier.AddNode(1, updates)
+	defer app.nodeNotifier.RemoveNode(1, updates)
+
+	c.Assert(app.broadcastMessage("Maintenance tonight", types.PolicyAuthorSocket), check.IsNil)
+	c.Assert(app.mapper.BroadcastMessage(), check.Equals, "Maintenance tonight")
+
+	update := <-updates
+	c.Assert(update.Type, check.Equals, types.StateFullUpdate)
+
+	// The message is shown again after a restart.
+	app.mapper = mapper.NewMapper(app.db, app.cfg, &tailcfg.DERPMap{}, app.nodeNotifier)
+	c.Assert(app.loadBroadcastMessage(), check.IsNil)
+	c.Assert(app.mapper.BroadcastMessage(), check.Equals, "Maintenance tonight")
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/fs"
+	"net/netip"
 	"net/url"
 	"os"
 	"path"
@@ -18,6 +19,7 @@ import (
 	"github.com/juanfont/headscale/hscontrol/db"
 	"github.com/juanfont/headscale/hscontrol/notifier"
 	"github.com/juanfont/headscale/hscontrol/policy"
+	"github.com/juanfont/headscale/hscontrol/policy/matcher"
 	"github.com/juanfont/headscale/hscontrol/types"
 	"github.com/juanfont/headscale/hscontrol/util"
 	"github.com/klauspost/compress/zstd"
@@ -26,6 +28,7 @@ import (
 	"tailscale.com/smallzstd"
 	"tailscale.com/tailcfg"
 	"tailscale.com/types/dnstype"
+	"tailscale.com/version"
 )
 
 const (
@@ -33,21 +36,28 @@ const (
 	reservedResponseHeaderSize = 4
 	mapperIDLength             = 8
 	debugMapResponsePerm       = 0o755
+
+	// viaDNSMinBits is the shortest IPv4 prefix of a 4via6 route that
+	// MagicDNS names are generated for, one name per address.
+	viaDNSMinBits = 24
+
+	// viaDNSMaxRecords is the largest number of names of 4via6 addresses
+	// sent to a node, the routes past it are left out.
+	viaDNSMaxRecords = 1024
 )
 
 var debugDumpMapResponsePath = envknob.String("HEADSCALE_DEBUG_DUMP_MAPRESPONSE_PATH")
 
 // TODO: Optimise
-// As this work continues, the idea is that there will be one Mapper instance
-// per node, attached to the open stream between the control and client.
-// This means that this can hold a state per node and we can use that to
-// improve the mapresponses sent.
 // We could:
-// - Keep information about the previous mapresponse so we can send a diff
 // - Store hashes
 // - Create a "minifier" that removes info not needed for the node
 // - some sort of batching, wait for 5 or 60 seconds before sending
 
+// Mapper creates the map responses sent to the nodes. There is one Mapper
+// instance per map session, attached to the open stream between the
+// control and client, see NewSession. It keeps the state of the map
+// responses sent, so a full update is sent as a diff.
 type Mapper struct {
 	// Configuration
 	// TODO(kradalby): figure out if this is the format we want this in
@@ -56,6 +66,25 @@ type Mapper struct {
 	derpMap *tailcfg.DERPMap
 	notif   *notifier.Notifier
 
+	// policy is shared by all map sessions, so the policy is only
+	// compiled when it or the nodes change.
+	policy *policy.Engine
+
+	// latestClientVersion is the latest stable Tailscale version,
+	// shared by all map sessions, see SetLatestClientVersion.
+	latestClientVersion *atomic.Pointer[string]
+
+	// broadcastMessage is shown to every node, see SetBroadcastMessage.
+	broadcastMessage *atomic.Pointer[string]
+
+	// dnsRecords are the DNS records managed through the API, sent
+	// along with the extra records of the configuration.
+	dnsRecords *atomic.Pointer[[]tailcfg.DNSRecord]
+
+	// state is what has been sent on the map session, it is nil if the
+	// Mapper is not used for a map session.
+	state *mapState
+
 	uid     string
 	created time.Time
 	seq     uint64
@@ -74,11 +103,45 @@ func NewMapper(
 ) *Mapper {
 	uid, _ := util.GenerateRandomStringDNSSafe(mapperIDLength)
 
+	engine := policy.NewEngine()
+	if notif != nil {
+		notif.OnNodesChanged(engine.NodesChanged)
+	}
+
 	return &Mapper{
 		db:      db,
 		cfg:     cfg,
 		derpMap: derpMap,
 		notif:   notif,
+		policy:  engine,
+
+		latestClientVersion: &atomic.Pointer[string]{},
+		broadcastMessage:    &atomic.Pointer[string]{},
+		dnsRecords:          &atomic.Pointer[[]tailcfg.DNSRecord]{},
+
+		uid:     uid,
+		created: time.Now(),
+		seq:     0,
+	}
+}
+
+// NewSession returns a Mapper for a map session, sharing the policy
+// engine of m. The first full update of the session sends the full map,
+// the next ones only send what changed since.
+func (m *Mapper) NewSession(derpMap *tailcfg.DERPMap) *Mapper {
+	uid, _ := util.GenerateRandomStringDNSSafe(mapperIDLength)
+
+	return &Mapper{
+		db:      m.db,
+		cfg:     m.cfg,
+		derpMap: derpMap,
+		notif:   m.notif,
+		policy:  m.policy,
+		state:   &mapState{},
+
+		latestClientVersion: m.latestClientVersion,
+		broadcastMessage:    m.broadcastMessage,
+		dnsRecords:          m.dnsRecords,
 
 		uid:     uid,
 		created: time.Now(),
@@ -86,6 +149,64 @@ func NewMapper(
 	}
 }
 
+// SetLatestClientVersion sets the latest stable Tailscale version, nodes
+// running an older version are told to update. An empty version tells
+// nothing to the nodes.
+func (m *Mapper) SetLatestClientVersion(version string) {
+	m.latestClientVersion.Store(&version)
+}
+
+// LatestClientVersion returns the version set with SetLatestClientVersion.
+func (m *Mapper) LatestClientVersion() string {
+	if version := m.latestClientVersion.Load(); version != nil {
+		return *version
+	}
+
+	return ""
+}
+
+// SetBroadcastMessage sets the message shown in the health UI of every
+// node. An empty message clears it.
+func (m *Mapper) SetBroadcastMessage(message string) {
+	m.broadcastMessage.Store(&message)
+}
+
+// BroadcastMessage returns the message set with SetBroadcastMessage.
+func (m *Mapper) BroadcastMessage() string {
+	if message := m.broadcastMessage.Load(); message != nil {
+		return *message
+	}
+
+	return ""
+}
+
+// SetDNSRecords sets the DNS records sent to the nodes along with the
+// extra records of the configuration.
+func (m *Mapper) SetDNSRecords(records []tailcfg.DNSRecord) {
+	m.dnsRecords.Store(&records)
+}
+
+// DNSRecords returns the records set with SetDNSRecords.
+func (m *Mapper) DNSRecords() []tailcfg.DNSRecord {
+	if records := m.dnsRecords.Load(); records != nil {
+		return *records
+	}
+
+	return nil
+}
+
+// CompilePolicy returns the policy compiled for all nodes, the one the
+// map responses are made from.
+func (m *Mapper) CompilePolicy(pol *policy.ACLPolicy) (*policy.CompiledPolicy, error) {
+	generation := m.policy.Generation()
+	nodes, err := m.db.ListNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.policy.Compile(pol, nodes, generation)
+}
+
 func (m *Mapper) String() string {
 	return fmt.Sprintf("Mapper: { seq: %d, uid: %s, created: %s }", m.seq, m.uid, m.created)
 }
@@ -112,18 +233,97 @@ func generateUserProfiles(
 func generateDNSConfig(
 	cfg *types.Config,
 	node *types.Node,
+	records []tailcfg.DNSRecord,
 ) *tailcfg.DNSConfig {
 	if cfg.DNSConfig == nil {
 		return nil
 	}
 
 	dnsConfig := cfg.DNSConfig.Clone()
+	dnsConfig.ExtraRecords = append(dnsConfig.ExtraRecords, records...)
 
 	addNextDNSMetadata(dnsConfig.Resolvers, node)
 
 	return dnsConfig
 }
 
+// addViaDNSRecords adds MagicDNS names for the addresses of the 4via6
+// routes of the peers, like 192-168-1-10-via-7.<base_domain>. Tailscale
+// clients only resolve these names themselves without a domain, or in
+// ts.net. Only the routes the node can reach with the rules are named,
+// up to viaDNSMaxRecords names.
+func addViaDNSRecords(
+	dnsConfig *tailcfg.DNSConfig,
+	cfg *types.Config,
+	node *types.Node,
+	rules []tailcfg.FilterRule,
+	peers types.Nodes,
+) {
+	if dnsConfig == nil || !cfg.DNSConfig.Proxied || cfg.BaseDomain == "" {
+		return
+	}
+
+	// The matchers of the rules the node is a source of, only built if a
+	// peer has a 4via6 route.
+	var matchers []matcher.Match
+	reachable := func(prefix netip.Prefix) bool {
+		if matchers == nil {
+			matchers = []matcher.Match{}
+			for _, rule := range rules {
+				match := matcher.MatchFromFilterRule(rule)
+				if match.SrcsContainsIPs(node.IPs()) {
+					matchers = append(matchers, match)
+				}
+			}
+		}
+
+		for _, match := range matchers {
+			if match.Dests.OverlapsPrefix(prefix) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	named := 0
+	seen := make(map[netip.Prefix]bool)
+	for _, peer := range peers {
+		for _, route := range peer.Routes {
+			prefix := netip.Prefix(route.Prefix)
+			if !route.IsAnnouncable() || !util.IsViaPrefix(prefix) || seen[prefix] {
+				continue
+			}
+			seen[prefix] = true
+
+			if util.ValidateViaPrefix(prefix) != nil {
+				continue
+			}
+
+			siteID, v4 := util.UnmapViaPrefix(prefix)
+			if v4.Bits() < viaDNSMinBits {
+				continue
+			}
+
+			size := 1 << (v4.Addr().BitLen() - v4.Bits())
+			if named+size > viaDNSMaxRecords || !reachable(prefix) {
+				continue
+			}
+			named += size
+
+			for addr := v4.Addr(); v4.Contains(addr); addr = addr.Next() {
+				via, _ := util.ViaPrefix(siteID, netip.PrefixFrom(addr, addr.BitLen()))
+
+				dnsConfig.ExtraRecords = append(dnsConfig.ExtraRecords, tailcfg.DNSRecord{
+					Name:  util.ViaDNSName(siteID, addr) + "." + cfg.BaseDomain,
+					Type:  "AAAA",
+					Value: via.Addr().String(),
+				})
+			}
+		}
+	}
+}
+
 // If any nextdns DoH resolvers are present in the list of resolvers it will
 // take metadata from the node metadata and instruct tailscale to add it
 // to the requests. This makes it possible to identify from which device the
@@ -150,9 +350,12 @@ func addNextDNSMetadata(resolvers []*dnstype.Resolver, node *types.Node) {
 
 // fullMapResponse creates a complete MapResponse for a node.
 // It is a separate function to make testing easier.
+// The generation of the nodes is the one read before loading the peers,
+// see policy.Engine.Generation.
 func (m *Mapper) fullMapResponse(
 	node *types.Node,
 	peers types.Nodes,
+	generation uint64,
 	pol *policy.ACLPolicy,
 	capVer tailcfg.CapabilityVersion,
 ) (*tailcfg.MapResponse, error) {
@@ -161,15 +364,22 @@ func (m *Mapper) fullMapResponse(
 		return nil, err
 	}
 
+	compiled, err := m.policy.Compile(pol, append(slices.Clone(peers), node), generation)
+	if err != nil {
+		return nil, err
+	}
+
 	err = appendPeerChanges(
 		resp,
 		true, // full change
 		pol,
+		compiled,
 		node,
 		capVer,
 		peers,
 		peers,
 		m.cfg,
+		m.DNSRecords(),
 	)
 	if err != nil {
 		return nil, err
@@ -179,22 +389,37 @@ func (m *Mapper) fullMapResponse(
 }
 
 // FullMapResponse returns a MapResponse for the given node.
+// On a map session that has already sent the full map, only the
+// difference is returned, or nil if nothing changed.
 func (m *Mapper) FullMapResponse(
 	mapRequest tailcfg.MapRequest,
 	node *types.Node,
 	pol *policy.ACLPolicy,
 	messages ...string,
 ) ([]byte, error) {
+	generation := m.policy.Generation()
 	peers, err := m.ListPeers(node.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := m.fullMapResponse(node, peers, pol, mapRequest.Version)
+	resp, err := m.fullMapResponse(node, peers, generation, pol, mapRequest.Version)
 	if err != nil {
 		return nil, err
 	}
 
+	if m.state != nil {
+		if m.state.synced {
+			resp = m.state.diff(resp)
+			if resp == nil {
+				return nil, nil
+			}
+			m.state.apply(resp)
+		} else {
+			m.state.reset(resp)
+		}
+	}
+
 	return m.marshalMapResponse(mapRequest, resp, node, mapRequest.Compress, messages...)
 }
 
@@ -235,6 +460,23 @@ func (m *Mapper) DERPMapResponse(
 	resp := m.baseMapResponse()
 	resp.DERPMap = derpMap
 
+	if m.state != nil {
+		m.state.apply(&resp)
+	}
+
+	return m.marshalMapResponse(mapRequest, &resp, node, mapRequest.Compress)
+}
+
+// PingRequestResponse returns a MapResponse asking the node to answer
+// the PingRequest.
+func (m *Mapper) PingRequestResponse(
+	mapRequest tailcfg.MapRequest,
+	node *types.Node,
+	pingRequest *tailcfg.PingRequest,
+) ([]byte, error) {
+	resp := m.baseMapResponse()
+	resp.PingRequest = pingRequest
+
 	return m.marshalMapResponse(mapRequest, &resp, node, mapRequest.Compress)
 }
 
@@ -248,6 +490,7 @@ func (m *Mapper) PeerChangedResponse(
 ) ([]byte, error) {
 	resp := m.baseMapResponse()
 
+	generation := m.policy.Generation()
 	peers, err := m.ListPeers(node.ID)
 	if err != nil {
 		return nil, err
@@ -270,15 +513,22 @@ func (m *Mapper) PeerChangedResponse(
 		}
 	}
 
+	compiled, err := m.policy.Compile(pol, append(slices.Clone(peers), node), generation)
+	if err != nil {
+		return nil, err
+	}
+
 	err = appendPeerChanges(
 		&resp,
 		false, // partial change
 		pol,
+		compiled,
 		node,
 		mapRequest.Version,
 		peers,
 		changedNodes,
 		m.cfg,
+		m.DNSRecords(),
 	)
 	if err != nil {
 		return nil, err
@@ -308,6 +558,13 @@ func (m *Mapper) PeerChangedResponse(
 		return nil, err
 	}
 	resp.Node = tailnode
+	resp.Health = HealthWarnings(node, m.cfg.Health, m.BroadcastMessage(), time.Now())
+
+	// Leave out what the node already has, on a map session.
+	if m.state != nil {
+		m.state.trim(&resp)
+		m.state.apply(&resp)
+	}
 
 	return m.marshalMapResponse(mapRequest, &resp, node, mapRequest.Compress, messages...)
 }
@@ -323,6 +580,10 @@ func (m *Mapper) PeerChangedPatchResponse(
 	resp := m.baseMapResponse()
 	resp.PeersChangedPatch = changed
 
+	if m.state != nil {
+		m.state.apply(&resp)
+	}
+
 	return m.marshalMapResponse(mapRequest, &resp, node, mapRequest.Compress)
 }
 
@@ -335,7 +596,18 @@ func (m *Mapper) marshalMapResponse(
 ) ([]byte, error) {
 	atomic.AddUint64(&m.seq, 1)
 
-	jsonBody, err := json.Marshal(resp)
+	var jsonBody []byte
+	var err error
+	if resp.Health != nil && len(resp.Health) == 0 {
+		// tailcfg.MapResponse leaves out an empty Health, which is how
+		// the health warnings of a node are cleared.
+		jsonBody, err = json.Marshal(struct {
+			*tailcfg.MapResponse
+			Health []string
+		}{resp, resp.Health})
+	} else {
+		jsonBody, err = json.Marshal(resp)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("marshalling map response: %w", err)
 	}
@@ -350,6 +622,8 @@ func (m *Mapper) marshalMapResponse(
 		responseType := "keepalive"
 
 		switch {
+		case resp.PingRequest != nil:
+			responseType = "ping"
 		case resp.Peers != nil && len(resp.Peers) > 0:
 			responseType = "full"
 		case resp.Peers == nil && resp.PeersChanged == nil && resp.PeersChangedPatch == nil && resp.DERPMap == nil && !resp.KeepAlive:
@@ -435,7 +709,6 @@ func (m *Mapper) baseMapResponse() tailcfg.MapResponse {
 	resp := tailcfg.MapResponse{
 		KeepAlive:   false,
 		ControlTime: &now,
-		// TODO(kradalby): Implement PingRequest?
 	}
 
 	return resp
@@ -472,9 +745,95 @@ func (m *Mapper) baseWithConfigMapResponse(
 		DisableLogTail: !m.cfg.LogTail.Enabled,
 	}
 
+	resp.ClientVersion = ClientVersion(node, m.LatestClientVersion(), m.cfg.ClientVersion)
+	resp.Health = HealthWarnings(node, m.cfg.Health, m.BroadcastMessage(), time.Now())
+
 	return &resp, nil
 }
 
+// ClientVersion returns whether the node runs the latest stable Tailscale
+// version, and if not, asks it to notify the user if configured. It
+// returns nil if either version is unknown.
+func ClientVersion(node *types.Node, latest string, cfg types.ClientVersionConfig) *tailcfg.ClientVersion {
+	if latest == "" || node.Hostinfo == nil {
+		return nil
+	}
+
+	// AtLeast is false for anything that does not parse as a version,
+	// like the "date.20240101" versions of unstable builds.
+	if !version.AtLeast(node.Hostinfo.IPNVersion, "0") {
+		return nil
+	}
+
+	if version.AtLeast(node.Hostinfo.IPNVersion, latest) {
+		return &tailcfg.ClientVersion{RunningLatest: true}
+	}
+
+	clientVersion := &tailcfg.ClientVersion{
+		LatestVersion: latest,
+	}
+
+	if cfg.Notify {
+		clientVersion.Notify = true
+		clientVersion.NotifyURL = cfg.NotifyURL
+		clientVersion.NotifyText = fmt.Sprintf("Tailscale %s is available.", latest)
+	}
+
+	return clientVersion
+}
+
+// HealthWarnings returns the problems of the node, shown in the health UI
+// of its client along with the message broadcast by the admins. The list
+// is empty, not nil, if there are none, which clears the warnings of the
+// client.
+func HealthWarnings(
+	node *types.Node,
+	cfg types.HealthConfig,
+	broadcast string,
+	now time.Time,
+) []string {
+	warnings := []string{}
+
+	if broadcast != "" {
+		warnings = append(warnings, broadcast)
+	}
+
+	if cfg.KeyExpiryWarning > 0 && node.Expiry != nil && !node.Expiry.IsZero() &&
+		node.Expiry.After(now) && node.Expiry.Sub(now) <= cfg.KeyExpiryWarning {
+		warnings = append(warnings, fmt.Sprintf(
+			"The key of this node expires on %s, reauthenticate to keep it connected.",
+			node.Expiry.UTC().Format("2006-01-02 15:04 MST"),
+		))
+	}
+
+	var exitNode bool
+	var routes []string
+	for _, route := range node.Routes {
+		if !route.Advertised || route.Enabled {
+			continue
+		}
+
+		if route.IsExitRoute() {
+			exitNode = true
+		} else {
+			routes = append(routes, netip.Prefix(route.Prefix).String())
+		}
+	}
+
+	if exitNode {
+		warnings = append(warnings, "This node is advertised as an exit node, but it has not been approved.")
+	}
+
+	if len(routes) > 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"This node advertises routes that have not been approved: %s.",
+			strings.Join(routes, ", "),
+		))
+	}
+
+	return warnings
+}
+
 func (m *Mapper) ListPeers(nodeID types.NodeID) (types.Nodes, error) {
 	peers, err := m.db.ListPeers(nodeID)
 	if err != nil {
@@ -506,18 +865,17 @@ func appendPeerChanges(
 
 	fullChange bool,
 	pol *policy.ACLPolicy,
+	compiled *policy.CompiledPolicy,
 	node *types.Node,
 	capVer tailcfg.CapabilityVersion,
 	peers types.Nodes,
 	changed types.Nodes,
 	cfg *types.Config,
+	dnsRecords []tailcfg.DNSRecord,
 ) error {
-	packetFilter, err := pol.CompileFilterRules(append(peers, node))
-	if err != nil {
-		return err
-	}
+	packetFilter := compiled.Rules()
 
-	sshPolicy, err := pol.CompileSSHPolicy(node, peers)
+	sshPolicy, err := compiled.SSHPolicy(node)
 	if err != nil {
 		return err
 	}
@@ -525,12 +883,20 @@ func appendPeerChanges(
 	// If there are filter rules present, see if there are any nodes that cannot
 	// access each-other at all and remove them from the peers.
 	if len(packetFilter) > 0 {
-		changed = policy.FilterNodesByACL(node, changed, packetFilter)
+		changed = compiled.Peers(node, changed)
 	}
 
 	profiles := generateUserProfiles(node, changed)
 
-	dnsConfig := generateDNSConfig(cfg, node)
+	dnsConfig := generateDNSConfig(cfg, node, dnsRecords)
+
+	// The names of 4via6 routes are added for all peers, not only the
+	// changed ones, as the DNS config is replaced as a whole.
+	visible := peers
+	if len(packetFilter) > 0 {
+		visible = compiled.Peers(node, peers)
+	}
+	addViaDNSRecords(dnsConfig, cfg, node, packetFilter, visible)
 
 	tailPeers, err := tailNodes(changed, capVer, pol, cfg)
 	if err != nil {
@@ -557,7 +923,7 @@ func appendPeerChanges(
 		// new PacketFilters field and "base" allows us to send a full update when we
 		// have to send an empty list, avoiding the hack in the else block.
 		resp.PacketFilters = map[string][]tailcfg.FilterRule{
-			"base": policy.ReduceFilterRules(node, packetFilter),
+			"base": compiled.ReducedRules(node),
 		}
 	} else {
 		// This is a hack to avoid sending an empty list of packet filters.
@@ -565,11 +931,18 @@ func appendPeerChanges(
 		// be omitted, causing the client to consider it unchanged, keeping the
 		// previous packet filter. Worst case, this can cause a node that previously
 		// has access to a node to _not_ loose access if an empty (allow none) is sent.
-		reduced := policy.ReduceFilterRules(node, packetFilter)
+		// The rules of the node itself are sent instead, as the rules of all nodes
+		// contain the autogroup:self rules of the other users.
+		reduced := compiled.ReducedRules(node)
 		if len(reduced) > 0 {
 			resp.PacketFilter = reduced
 		} else {
-			resp.PacketFilter = packetFilter
+			own, err := compiled.NodeRules(node)
+			if err != nil {
+				return err
+			}
+
+			resp.PacketFilter = own
 		}
 	}
 
@@ -1,14 +1,17 @@
 package mapper
 
 import (
+	"context"
 	"fmt"
 	"net/netip"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/juanfont/headscale/hscontrol/notifier"
 	"github.com/juanfont/headscale/hscontrol/policy"
 	"github.com/juanfont/headscale/hscontrol/types"
 	"gopkg.in/check.v1"
@@ -72,16 +75,23 @@ func (s *Suite) TestGetMapResponseUserProfiles(c *check.C) {
 func TestDNSConfigMapResponse(t *testing.T) {
 	tests := []struct {
 		magicDNS bool
+		records  []tailcfg.DNSRecord
 		want     *tailcfg.DNSConfig
 	}{
 		{
 			magicDNS: true,
+			records: []tailcfg.DNSRecord{
+				{Name: "ci-42.foobar.headscale.net", Type: "A", Value: "100.64.0.7"},
+			},
 			want: &tailcfg.DNSConfig{
 				Routes: map[string][]*dnstype.Resolver{},
 				Domains: []string{
 					"foobar.headscale.net",
 				},
 				Proxied: true,
+				ExtraRecords: []tailcfg.DNSRecord{
+					{Name: "ci-42.foobar.headscale.net", Type: "A", Value: "100.64.0.7"},
+				},
 			},
 		},
 		{
@@ -120,6 +130,7 @@ func TestDNSConfigMapResponse(t *testing.T) {
 					DNSConfig: &dnsConfigOrig,
 				},
 				nodeInShared1,
+				tt.records,
 			)
 
 			if diff := cmp.Diff(tt.want, got, cmpopts.EquateEmpty()); diff != "" {
@@ -129,6 +140,110 @@ func TestDNSConfigMapResponse(t *testing.T) {
 	}
 }
 
+func TestAddViaDNSRecords(t *testing.T) {
+	cfg := &types.Config{
+		BaseDomain: "example.com",
+		DNSConfig:  &tailcfg.DNSConfig{Proxied: true},
+	}
+
+	route := func(prefix string, enabled bool) types.Route {
+		return types.Route{
+			Prefix:     netip.MustParsePrefix(prefix),
+			Advertised: true,
+			Enabled:    enabled,
+		}
+	}
+
+	peers := types.Nodes{
+		&types.Node{
+			ID: 1,
+			Routes: types.Routes{
+				// 192.168.1.0/30 at site 7.
+				route("fd7a:115c:a1e0:b1a:0:7:c0a8:100/126", true),
+				// Too many addresses to name.
+				route("fd7a:115c:a1e0:b1a:0:7:a00:0/104", true),
+				route("fd7a:115c:a1e0:b1a:0:8:c0a8:100/126", false),
+				route("10.0.0.0/8", true),
+			},
+		},
+		&types.Node{
+			ID: 2,
+			Routes: types.Routes{
+				// The same route at another site.
+				route("fd7a:115c:a1e0:b1a:0:9:c0a8:100/127", true),
+			},
+		},
+		&types.Node{
+			ID: 3,
+			Routes: types.Routes{
+				// A site the node cannot reach.
+				route("fd7a:115c:a1e0:b1a:0:b:c0a8:100/126", true),
+			},
+		},
+	}
+
+	node := &types.Node{ID: 4, IPv4: iap("100.64.0.4")}
+	rules := []tailcfg.FilterRule{
+		{
+			SrcIPs: []string{"100.64.0.4/32"},
+			DstPorts: []tailcfg.NetPortRange{
+				{IP: "fd7a:115c:a1e0:b1a:0:7:c0a8:100/120", Ports: tailcfg.PortRangeAny},
+				{IP: "fd7a:115c:a1e0:b1a:0:8:c0a8:100/120", Ports: tailcfg.PortRangeAny},
+				{IP: "fd7a:115c:a1e0:b1a:0:9:c0a8:100/120", Ports: tailcfg.PortRangeAny},
+				{IP: "fd7a:115c:a1e0:b1a:0:7:a00:0/104", Ports: tailcfg.PortRangeAny},
+			},
+		},
+		{
+			SrcIPs: []string{"100.64.0.5/32"},
+			DstPorts: []tailcfg.NetPortRange{
+				{IP: "*", Ports: tailcfg.PortRangeAny},
+			},
+		},
+	}
+
+	dnsConfig := &tailcfg.DNSConfig{}
+	addViaDNSRecords(dnsConfig, cfg, node, rules, peers)
+
+	want := []tailcfg.DNSRecord{
+		{Name: "192-168-1-0-via-7.example.com", Type: "AAAA", Value: "fd7a:115c:a1e0:b1a:0:7:c0a8:100"},
+		{Name: "192-168-1-1-via-7.example.com", Type: "AAAA", Value: "fd7a:115c:a1e0:b1a:0:7:c0a8:101"},
+		{Name: "192-168-1-2-via-7.example.com", Type: "AAAA", Value: "fd7a:115c:a1e0:b1a:0:7:c0a8:102"},
+		{Name: "192-168-1-3-via-7.example.com", Type: "AAAA", Value: "fd7a:115c:a1e0:b1a:0:7:c0a8:103"},
+		{Name: "192-168-1-0-via-9.example.com", Type: "AAAA", Value: "fd7a:115c:a1e0:b1a:0:9:c0a8:100"},
+		{Name: "192-168-1-1-via-9.example.com", Type: "AAAA", Value: "fd7a:115c:a1e0:b1a:0:9:c0a8:101"},
+	}
+
+	if diff := cmp.Diff(want, dnsConfig.ExtraRecords); diff != "" {
+		t.Errorf("addViaDNSRecords() unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Without MagicDNS, no names are added.
+	dnsConfig = &tailcfg.DNSConfig{}
+	addViaDNSRecords(dnsConfig, &types.Config{
+		BaseDomain: "example.com",
+		DNSConfig:  &tailcfg.DNSConfig{},
+	}, node, rules, peers)
+
+	if len(dnsConfig.ExtraRecords) != 0 {
+		t.Errorf("addViaDNSRecords() added %d records without MagicDNS", len(dnsConfig.ExtraRecords))
+	}
+
+	// The names of the routes past viaDNSMaxRecords are left out.
+	var routes types.Routes
+	for site := 1; site <= viaDNSMaxRecords/256+1; site++ {
+		routes = append(routes, route(fmt.Sprintf("fd7a:115c:a1e0:b1a:0:%x:c0a8:100/120", site), true))
+	}
+
+	dnsConfig = &tailcfg.DNSConfig{}
+	addViaDNSRecords(dnsConfig, cfg, node, tailcfg.FilterAllowAll, types.Nodes{
+		&types.Node{ID: 1, Routes: routes},
+	})
+
+	if len(dnsConfig.ExtraRecords) != viaDNSMaxRecords {
+		t.Errorf("addViaDNSRecords() added %d records, want %d", len(dnsConfig.ExtraRecords), viaDNSMaxRecords)
+	}
+}
+
 func Test_fullMapResponse(t *testing.T) {
 	mustNK := func(str string) key.NodePublic {
 		var k key.NodePublic
@@ -360,6 +475,7 @@ func Test_fullMapResponse(t *testing.T) {
 				CollectServices: "false",
 				PacketFilter:    []tailcfg.FilterRule{},
 				UserProfiles:    []tailcfg.UserProfile{{LoginName: "mini", DisplayName: "mini"}},
+				Health:          []string{"This node advertises routes that have not been approved: 172.0.0.0/10."},
 				SSHPolicy:       &tailcfg.SSHPolicy{Rules: []*tailcfg.SSHRule{}},
 				ControlTime:     &time.Time{},
 				Debug: &tailcfg.Debug{
@@ -394,6 +510,7 @@ func Test_fullMapResponse(t *testing.T) {
 				CollectServices: "false",
 				PacketFilter:    []tailcfg.FilterRule{},
 				UserProfiles:    []tailcfg.UserProfile{{LoginName: "mini", DisplayName: "mini"}},
+				Health:          []string{"This node advertises routes that have not been approved: 172.0.0.0/10."},
 				SSHPolicy:       &tailcfg.SSHPolicy{Rules: []*tailcfg.SSHRule{}},
 				ControlTime:     &time.Time{},
 				Debug: &tailcfg.Debug{
@@ -446,6 +563,7 @@ func Test_fullMapResponse(t *testing.T) {
 				UserProfiles: []tailcfg.UserProfile{
 					{LoginName: "mini", DisplayName: "mini"},
 				},
+				Health:      []string{"This node advertises routes that have not been approved: 172.0.0.0/10."},
 				SSHPolicy:   &tailcfg.SSHPolicy{Rules: []*tailcfg.SSHRule{}},
 				ControlTime: &time.Time{},
 				Debug: &tailcfg.Debug{
@@ -468,6 +586,7 @@ func Test_fullMapResponse(t *testing.T) {
 			got, err := mappy.fullMapResponse(
 				tt.node,
 				tt.peers,
+				mappy.policy.Generation(),
 				tt.pol,
 				0,
 			)
@@ -492,3 +611,311 @@ func Test_fullMapResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestClientVersion(t *testing.T) {
+	withVersion := func(version string) *types.Node {
+		return &types.Node{Hostinfo: &tailcfg.Hostinfo{IPNVersion: version}}
+	}
+
+	notify := types.ClientVersionConfig{
+		Notify:    true,
+		NotifyURL: "https://tailscale.com/changelog",
+	}
+
+	tests := []struct {
+		name   string
+		node   *types.Node
+		latest string
+		cfg    types.ClientVersionConfig
+		want   *tailcfg.ClientVersion
+	}{
+		{
+			name:   "latest-unknown",
+			node:   withVersion("1.74.1-t2ab2e6a3c-ge9b7b5d1f"),
+			latest: "",
+			want:   nil,
+		},
+		{
+			name:   "no-hostinfo",
+			node:   &types.Node{},
+			latest: "1.76.0",
+			want:   nil,
+		},
+		{
+			name:   "unstable-build",
+			node:   withVersion("date.20240101"),
+			latest: "1.76.0",
+			want:   nil,
+		},
+		{
+			name:   "running-latest",
+			node:   withVersion("1.76.0-t8e2b7a9c1-g0d1e2f3a4"),
+			latest: "1.76.0",
+			want:   &tailcfg.ClientVersion{RunningLatest: true},
+		},
+		{
+			name:   "running-newer",
+			node:   withVersion("1.77.12"),
+			latest: "1.76.0",
+			want:   &tailcfg.ClientVersion{RunningLatest: true},
+		},
+		{
+			name:   "outdated",
+			node:   withVersion("1.74.1-t2ab2e6a3c-ge9b7b5d1f"),
+			latest: "1.76.0",
+			want:   &tailcfg.ClientVersion{LatestVersion: "1.76.0"},
+		},
+		{
+			name:   "outdated-notify",
+			node:   withVersion("1.74.1-t2ab2e6a3c-ge9b7b5d1f"),
+			latest: "1.76.0",
+			cfg:    notify,
+			want: &tailcfg.ClientVersion{
+				LatestVersion: "1.76.0",
+				Notify:        true,
+				NotifyURL:     "https://tailscale.com/changelog",
+				NotifyText:    "Tailscale 1.76.0 is available.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClientVersion(tt.node, tt.latest, tt.cfg)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ClientVersion() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHealthWarnings(t *testing.T) {
+	now := time.Date(2024, time.October, 1, 12, 0, 0, 0, time.UTC)
+	soon := now.Add(48 * time.Hour)
+	later := now.Add(30 * 24 * time.Hour)
+	expired := now.Add(-time.Hour)
+
+	route := func(prefix string, enabled bool) types.Route {
+		return types.Route{
+			Prefix:     netip.MustParsePrefix(prefix),
+			Advertised: true,
+			Enabled:    enabled,
+		}
+	}
+
+	cfg := types.HealthConfig{KeyExpiryWarning: 7 * 24 * time.Hour}
+
+	tests := []struct {
+		name      string
+		node      *types.Node
+		cfg       types.HealthConfig
+		broadcast string
+		want      []string
+	}{
+		{
+			name: "healthy",
+			node: &types.Node{
+				Expiry: &later,
+				Routes: types.Routes{route("10.0.0.0/24", true), route("0.0.0.0/0", true)},
+			},
+			cfg:  cfg,
+			want: []string{},
+		},
+		{
+			name: "key-expires-soon",
+			node: &types.Node{Expiry: &soon},
+			cfg:  cfg,
+			want: []string{
+				"The key of this node expires on 2024-10-03 12:00 UTC, reauthenticate to keep it connected.",
+			},
+		},
+		{
+			name: "key-expiry-warning-disabled",
+			node: &types.Node{Expiry: &soon},
+			want: []string{},
+		},
+		{
+			name: "key-expired",
+			node: &types.Node{Expiry: &expired},
+			cfg:  cfg,
+			want: []string{},
+		},
+		{
+			name: "routes-not-approved",
+			node: &types.Node{
+				Routes: types.Routes{
+					route("10.0.0.0/24", false),
+					route("10.1.0.0/24", true),
+					route("192.168.0.0/24", false),
+					route("0.0.0.0/0", false),
+					route("::/0", false),
+				},
+			},
+			cfg: cfg,
+			want: []string{
+				"This node is advertised as an exit node, but it has not been approved.",
+				"This node advertises routes that have not been approved: 10.0.0.0/24, 192.168.0.0/24.",
+			},
+		},
+		{
+			name:      "broadcast",
+			node:      &types.Node{Expiry: &soon},
+			cfg:       cfg,
+			broadcast: "Maintenance tonight at 22:00 UTC",
+			want: []string{
+				"Maintenance tonight at 22:00 UTC",
+				"The key of this node expires on 2024-10-03 12:00 UTC, reauthenticate to keep it connected.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HealthWarnings(tt.node, tt.cfg, tt.broadcast, now)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("HealthWarnings() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMarshalEmptyHealth(t *testing.T) {
+	mappy := NewMapper(nil, &types.Config{}, &tailcfg.DERPMap{}, nil)
+
+	for _, tt := range []struct {
+		health []string
+		want   string
+	}{
+		{health: nil, want: ""},
+		{health: []string{}, want: `"Health":[]`},
+		{health: []string{"warning"}, want: `"Health":["warning"]`},
+	} {
+		resp := &tailcfg.MapResponse{KeepAlive: true, Health: tt.health}
+
+		body, err := mappy.marshalMapResponse(tailcfg.MapRequest{}, resp, &types.Node{}, "")
+		if err != nil {
+			t.Fatalf("marshalMapResponse() error = %v", err)
+		}
+
+		// The body is prefixed with its length.
+		got := string(body[reservedResponseHeaderSize:])
+		if tt.want == "" && strings.Contains(got, "Health") {
+			t.Errorf("marshalMapResponse(%v) = %s, want no Health", tt.health, got)
+		}
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("marshalMapResponse(%v) = %s, want %s", tt.health, got, tt.want)
+		}
+	}
+}
+
+func TestPacketFilterBeforeCapVer81(t *testing.T) {
+	user1 := types.User{Model: gorm.Model{ID: 1}, Name: "user1"}
+	user2 := types.User{Model: gorm.Model{ID: 2}, Name: "user2"}
+
+	node1 := &types.Node{ID: 1, Hostname: "node1", GivenName: "node1", IPv4: iap("100.64.0.1"), User: user1, UserID: user1.ID}
+	node2 := &types.Node{ID: 2, Hostname: "node2", GivenName: "node2", IPv4: iap("100.64.0.2"), User: user2, UserID: user2.ID}
+	node3 := &types.Node{ID: 3, Hostname: "node3", GivenName: "node3", IPv4: iap("100.64.0.3"), User: user2, UserID: user2.ID}
+
+	// node1 is not the destination of any rule, so its reduced packet
+	// filter is empty, and it must not receive the autogroup:self rule of
+	// user2.
+	pol := &policy.ACLPolicy{
+		ACLs: []policy.ACL{
+			{
+				Action:       "accept",
+				Sources:      []string{"user1"},
+				Destinations: []string{"user2:22"},
+			},
+			{
+				Action:       "accept",
+				Sources:      []string{"user2"},
+				Destinations: []string{"autogroup:self:*"},
+			},
+		},
+	}
+
+	want := []tailcfg.FilterRule{
+		{
+			SrcIPs: []string{"100.64.0.1/32"},
+			DstPorts: []tailcfg.NetPortRange{
+				{IP: "100.64.0.2/31", Ports: tailcfg.PortRange{First: 22, Last: 22}},
+			},
+		},
+	}
+
+	mappy := NewMapper(nil, &types.Config{DNSConfig: &tailcfg.DNSConfig{}}, &tailcfg.DERPMap{}, nil)
+
+	got, err := mappy.fullMapResponse(node1, types.Nodes{node2, node3}, mappy.policy.Generation(), pol, 80)
+	if err != nil {
+		t.Fatalf("fullMapResponse() error = %v", err)
+	}
+
+	if diff := cmp.Diff(want, got.PacketFilter, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("fullMapResponse() unexpected PacketFilter (-want +got):\n%s", diff)
+	}
+}
+
+func TestEndpointUpdateKeepsCompiledPolicy(t *testing.T) {
+	user1 := types.User{Model: gorm.Model{ID: 1}, Name: "user1"}
+
+	nodes := types.Nodes{
+		&types.Node{ID: 1, Hostname: "node1", GivenName: "node1", IPv4: iap("100.64.0.1"), User: user1, UserID: user1.ID},
+		&types.Node{ID: 2, Hostname: "node2", GivenName: "node2", IPv4: iap("100.64.0.2"), User: user1, UserID: user1.ID},
+	}
+
+	pol := &policy.ACLPolicy{
+		ACLs: []policy.ACL{
+			{
+				Action:       "accept",
+				Sources:      []string{"user1"},
+				Destinations: []string{"user1:*"},
+			},
+		},
+	}
+
+	notif := notifier.NewNotifier(&types.Config{
+		Tuning: types.Tuning{
+			BatchChangeDelay:    time.Hour,
+			NotifierSendTimeout: time.Second,
+		},
+	})
+	mappy := NewMapper(nil, &types.Config{DNSConfig: &tailcfg.DNSConfig{}}, &tailcfg.DERPMap{}, notif)
+
+	compiled, err := mappy.policy.Compile(pol, nodes, mappy.policy.Generation())
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	// The update sent to the peers of a node when its endpoints, DERP
+	// region or Hostinfo change.
+	notif.NotifyWithIgnore(context.Background(), types.StateUpdate{
+		Type:        types.StatePeerChanged,
+		ChangeNodes: []types.NodeID{1},
+	}, 1)
+	notif.NotifyWithIgnore(context.Background(), types.StateUpdate{
+		Type: types.StatePeerChangedPatch,
+		ChangePatches: []*tailcfg.PeerChange{
+			{NodeID: 1, DERPRegion: 6},
+		},
+	}, 1)
+
+	got, err := mappy.policy.Compile(pol, nodes, mappy.policy.Generation())
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	if got != compiled {
+		t.Errorf("Compile() compiled the policy again after an endpoint update")
+	}
+
+	notif.NodesChanged()
+
+	got, err = mappy.policy.Compile(pol, nodes, mappy.policy.Generation())
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	if got == compiled {
+		t.Errorf("Compile() kept the compiled policy after the nodes changed")
+	}
+}
@@ -0,0 +1,339 @@
+package mapper
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"tailscale.com/tailcfg"
+)
+
+// mapState mirrors the netmap a node builds from the map responses sent
+// to it on a long-poll connection. Map responses leave out what has not
+// changed, so once a full map has been sent, a full update only needs to
+// send the difference with the mapState.
+type mapState struct {
+	// synced is set once a full map has been sent.
+	synced bool
+
+	node          *tailcfg.Node
+	peers         map[tailcfg.NodeID]*tailcfg.Node
+	userProfiles  map[tailcfg.UserID]tailcfg.UserProfile
+	dnsConfig     *tailcfg.DNSConfig
+	packetFilter  []tailcfg.FilterRule
+	packetFilters map[string][]tailcfg.FilterRule
+	sshPolicy     *tailcfg.SSHPolicy
+	derpMap       *tailcfg.DERPMap
+	domain        string
+	debug         *tailcfg.Debug
+	clientVersion *tailcfg.ClientVersion
+	health        []string
+}
+
+// reset replaces the state with a full map response.
+func (s *mapState) reset(resp *tailcfg.MapResponse) {
+	*s = mapState{
+		synced:       true,
+		peers:        make(map[tailcfg.NodeID]*tailcfg.Node, len(resp.Peers)),
+		userProfiles: make(map[tailcfg.UserID]tailcfg.UserProfile),
+	}
+
+	for _, peer := range resp.Peers {
+		s.peers[peer.ID] = peer
+	}
+
+	s.apply(resp)
+}
+
+// apply updates the state with a map response the way a Tailscale client
+// applies it to its netmap: nil and empty fields are unchanged.
+func (s *mapState) apply(resp *tailcfg.MapResponse) {
+	if !s.synced {
+		return
+	}
+
+	if resp.Node != nil {
+		s.node = resp.Node
+	}
+
+	for _, peer := range resp.PeersChanged {
+		s.peers[peer.ID] = peer
+	}
+
+	for _, id := range resp.PeersRemoved {
+		delete(s.peers, id)
+	}
+
+	for _, change := range resp.PeersChangedPatch {
+		if peer, ok := s.peers[change.NodeID]; ok {
+			s.peers[change.NodeID] = patchNode(peer, change)
+		}
+	}
+
+	for _, profile := range resp.UserProfiles {
+		s.userProfiles[profile.ID] = profile
+	}
+
+	if resp.DNSConfig != nil {
+		s.dnsConfig = resp.DNSConfig
+	}
+
+	if resp.PacketFilter != nil {
+		s.packetFilter = resp.PacketFilter
+	}
+
+	if resp.PacketFilters != nil {
+		s.packetFilters = resp.PacketFilters
+	}
+
+	if resp.SSHPolicy != nil {
+		s.sshPolicy = resp.SSHPolicy
+	}
+
+	if resp.DERPMap != nil {
+		s.derpMap = resp.DERPMap
+	}
+
+	if resp.Domain != "" {
+		s.domain = resp.Domain
+	}
+
+	if resp.Debug != nil {
+		s.debug = resp.Debug
+	}
+
+	if resp.ClientVersion != nil {
+		s.clientVersion = resp.ClientVersion
+	}
+
+	if resp.Health != nil {
+		s.health = resp.Health
+	}
+}
+
+// diff returns the map response turning the state into the full map
+// response, or nil if the node is up to date. Peers that are new or
+// changed are sent in PeersChanged, unless only fields of a
+// tailcfg.PeerChange changed, then they are sent in PeersChangedPatch.
+func (s *mapState) diff(full *tailcfg.MapResponse) *tailcfg.MapResponse {
+	resp := *full
+	resp.Peers = nil
+
+	seen := make(map[tailcfg.NodeID]bool, len(full.Peers))
+	for _, peer := range full.Peers {
+		seen[peer.ID] = true
+
+		old, ok := s.peers[peer.ID]
+		if !ok {
+			resp.PeersChanged = append(resp.PeersChanged, peer)
+
+			continue
+		}
+
+		change, ok := peerChange(old, peer)
+		if !ok {
+			resp.PeersChanged = append(resp.PeersChanged, peer)
+		} else if change != nil {
+			resp.PeersChangedPatch = append(resp.PeersChangedPatch, change)
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(s.peers)) {
+		if !seen[id] {
+			resp.PeersRemoved = append(resp.PeersRemoved, id)
+		}
+	}
+
+	resp.UserProfiles = nil
+	for _, profile := range full.UserProfiles {
+		if old, ok := s.userProfiles[profile.ID]; !ok || !reflect.DeepEqual(old, profile) {
+			resp.UserProfiles = append(resp.UserProfiles, profile)
+		}
+	}
+
+	if resp.DERPMap == s.derpMap || reflect.DeepEqual(resp.DERPMap, s.derpMap) {
+		resp.DERPMap = nil
+	}
+
+	if resp.Domain == s.domain {
+		resp.Domain = ""
+	}
+
+	if reflect.DeepEqual(resp.Debug, s.debug) {
+		resp.Debug = nil
+	}
+
+	if reflect.DeepEqual(resp.ClientVersion, s.clientVersion) {
+		resp.ClientVersion = nil
+	}
+
+	// CollectServices is always false, and is only sent with the full map.
+	resp.CollectServices = ""
+
+	s.trim(&resp)
+
+	if resp.Node == nil &&
+		resp.PeersChanged == nil &&
+		resp.PeersRemoved == nil &&
+		resp.PeersChangedPatch == nil &&
+		resp.UserProfiles == nil &&
+		resp.DNSConfig == nil &&
+		resp.PacketFilter == nil &&
+		resp.PacketFilters == nil &&
+		resp.SSHPolicy == nil &&
+		resp.DERPMap == nil &&
+		resp.Domain == "" &&
+		resp.Debug == nil &&
+		resp.ClientVersion == nil &&
+		resp.Health == nil {
+		return nil
+	}
+
+	return &resp
+}
+
+// trim removes the node, DNS config, packet filter, SSH policy and health
+// warnings from a map response if they are the ones the node already has.
+func (s *mapState) trim(resp *tailcfg.MapResponse) {
+	if !s.synced {
+		return
+	}
+
+	if resp.Node != nil && resp.Node.Equal(s.node) {
+		resp.Node = nil
+	}
+
+	if resp.DNSConfig != nil && reflect.DeepEqual(resp.DNSConfig, s.dnsConfig) {
+		resp.DNSConfig = nil
+	}
+
+	if resp.PacketFilter != nil && reflect.DeepEqual(resp.PacketFilter, s.packetFilter) {
+		resp.PacketFilter = nil
+	}
+
+	if resp.PacketFilters != nil && reflect.DeepEqual(resp.PacketFilters, s.packetFilters) {
+		resp.PacketFilters = nil
+	}
+
+	if resp.SSHPolicy != nil && reflect.DeepEqual(resp.SSHPolicy, s.sshPolicy) {
+		resp.SSHPolicy = nil
+	}
+
+	if resp.Health != nil && slices.Equal(resp.Health, s.health) {
+		resp.Health = nil
+	}
+}
+
+// peerChange returns the tailcfg.PeerChange turning old into peer, nil
+// if they are equal. It returns false if fields that cannot be patched
+// changed, and the whole node has to be sent.
+func peerChange(old, peer *tailcfg.Node) (*tailcfg.PeerChange, bool) {
+	if old.Equal(peer) {
+		return nil, true
+	}
+
+	change := &tailcfg.PeerChange{NodeID: peer.ID}
+
+	if old.Key != peer.Key {
+		change.Key = &peer.Key
+	}
+
+	if !old.KeyExpiry.Equal(peer.KeyExpiry) {
+		change.KeyExpiry = &peer.KeyExpiry
+	}
+
+	if old.DiscoKey != peer.DiscoKey {
+		change.DiscoKey = &peer.DiscoKey
+	}
+
+	if !slices.Equal(old.Endpoints, peer.Endpoints) {
+		change.Endpoints = peer.Endpoints
+	}
+
+	if old.DERP != peer.DERP {
+		region, ok := derpRegion(peer.DERP)
+		if !ok {
+			return nil, false
+		}
+		change.DERPRegion = region
+	}
+
+	if old.Cap != peer.Cap {
+		change.Cap = peer.Cap
+	}
+
+	if peer.Online != nil && (old.Online == nil || *old.Online != *peer.Online) {
+		change.Online = peer.Online
+	}
+
+	if peer.LastSeen != nil && (old.LastSeen == nil || !old.LastSeen.Equal(*peer.LastSeen)) {
+		change.LastSeen = peer.LastSeen
+	}
+
+	// Every other field must be unchanged.
+	if !patchNode(old, change).Equal(peer) {
+		return nil, false
+	}
+
+	return change, true
+}
+
+// patchNode returns a copy of the node with the change applied.
+func patchNode(node *tailcfg.Node, change *tailcfg.PeerChange) *tailcfg.Node {
+	patched := node.Clone()
+
+	if change.Key != nil {
+		patched.Key = *change.Key
+	}
+
+	if change.KeyExpiry != nil {
+		patched.KeyExpiry = *change.KeyExpiry
+	}
+
+	if change.DiscoKey != nil {
+		patched.DiscoKey = *change.DiscoKey
+	}
+
+	if change.Endpoints != nil {
+		patched.Endpoints = change.Endpoints
+	}
+
+	if change.DERPRegion != 0 {
+		patched.DERP = fmt.Sprintf("%s:%d", tailcfg.DerpMagicIP, change.DERPRegion)
+	}
+
+	if change.Cap != 0 {
+		patched.Cap = change.Cap
+	}
+
+	if change.Online != nil {
+		online := *change.Online
+		patched.Online = &online
+	}
+
+	if change.LastSeen != nil {
+		lastSeen := *change.LastSeen
+		patched.LastSeen = &lastSeen
+	}
+
+	return patched
+}
+
+// derpRegion returns the region of a DERP address of a node, like
+// 127.3.3.40:1.
+func derpRegion(derp string) (int, bool) {
+	ip, port, err := net.SplitHostPort(derp)
+	if err != nil || ip != tailcfg.DerpMagicIP {
+		return 0, false
+	}
+
+	region, err := strconv.Atoi(port)
+	if err != nil || region <= 0 {
+		return 0, false
+	}
+
+	return region, true
+}
@@ -0,0 +1,166 @@
+package mapper
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"tailscale.com/tailcfg"
+	"tailscale.com/types/dnstype"
+	"tailscale.com/types/ptr"
+)
+
+func TestMapStateDiff(t *testing.T) {
+	lastSeen := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+
+	peer := func(id tailcfg.NodeID, name string) *tailcfg.Node {
+		return &tailcfg.Node{
+			ID:        id,
+			Name:      name,
+			Addresses: []netip.Prefix{netip.MustParsePrefix("100.64.0.1/32")},
+			DERP:      "127.3.3.40:1",
+			Online:    ptr.To(true),
+			LastSeen:  &lastSeen,
+		}
+	}
+
+	full := func(mod func(*tailcfg.MapResponse)) *tailcfg.MapResponse {
+		resp := &tailcfg.MapResponse{
+			Node:  &tailcfg.Node{ID: 1, Name: "self"},
+			Peers: []*tailcfg.Node{peer(2, "peer1"), peer(3, "peer2")},
+			UserProfiles: []tailcfg.UserProfile{
+				{ID: 1, LoginName: "user1"},
+			},
+			DNSConfig: &tailcfg.DNSConfig{
+				Resolvers: []*dnstype.Resolver{{Addr: "1.1.1.1"}},
+			},
+			PacketFilters: map[string][]tailcfg.FilterRule{
+				"base": {{SrcIPs: []string{"*"}}},
+			},
+			SSHPolicy:       &tailcfg.SSHPolicy{},
+			DERPMap:         &tailcfg.DERPMap{Regions: map[int]*tailcfg.DERPRegion{1: {RegionID: 1}}},
+			Domain:          "example.com",
+			CollectServices: "false",
+			Debug:           &tailcfg.Debug{DisableLogTail: true},
+			ClientVersion:   &tailcfg.ClientVersion{RunningLatest: true},
+		}
+		if mod != nil {
+			mod(resp)
+		}
+
+		return resp
+	}
+
+	tests := []struct {
+		name string
+		full *tailcfg.MapResponse
+		want *tailcfg.MapResponse
+	}{
+		{
+			name: "unchanged",
+			full: full(nil),
+			want: nil,
+		},
+		{
+			name: "peer-online-patch",
+			full: full(func(resp *tailcfg.MapResponse) {
+				resp.Peers[0].Online = ptr.To(false)
+				resp.Peers[0].DERP = "127.3.3.40:2"
+			}),
+			want: &tailcfg.MapResponse{
+				PeersChangedPatch: []*tailcfg.PeerChange{
+					{NodeID: 2, Online: ptr.To(false), DERPRegion: 2},
+				},
+			},
+		},
+		{
+			name: "peer-renamed",
+			full: full(func(resp *tailcfg.MapResponse) {
+				resp.Peers[1].Name = "renamed"
+			}),
+			want: &tailcfg.MapResponse{
+				PeersChanged: []*tailcfg.Node{peer(3, "renamed")},
+			},
+		},
+		{
+			name: "peer-added-and-removed",
+			full: full(func(resp *tailcfg.MapResponse) {
+				resp.Peers = []*tailcfg.Node{peer(2, "peer1"), peer(4, "peer3")}
+				resp.UserProfiles = append(resp.UserProfiles, tailcfg.UserProfile{ID: 2, LoginName: "user2"})
+			}),
+			want: &tailcfg.MapResponse{
+				PeersChanged: []*tailcfg.Node{peer(4, "peer3")},
+				PeersRemoved: []tailcfg.NodeID{3},
+				UserProfiles: []tailcfg.UserProfile{{ID: 2, LoginName: "user2"}},
+			},
+		},
+		{
+			name: "policy-changed",
+			full: full(func(resp *tailcfg.MapResponse) {
+				resp.PacketFilters = map[string][]tailcfg.FilterRule{
+					"base": {{SrcIPs: []string{"100.64.0.2/32"}}},
+				}
+				resp.SSHPolicy = &tailcfg.SSHPolicy{Rules: []*tailcfg.SSHRule{{SSHUsers: map[string]string{"*": "="}}}}
+			}),
+			want: &tailcfg.MapResponse{
+				PacketFilters: map[string][]tailcfg.FilterRule{
+					"base": {{SrcIPs: []string{"100.64.0.2/32"}}},
+				},
+				SSHPolicy: &tailcfg.SSHPolicy{Rules: []*tailcfg.SSHRule{{SSHUsers: map[string]string{"*": "="}}}},
+			},
+		},
+		{
+			name: "self-and-dns-changed",
+			full: full(func(resp *tailcfg.MapResponse) {
+				resp.Node.Name = "self-renamed"
+				resp.DNSConfig.Resolvers[0].Addr = "9.9.9.9"
+			}),
+			want: &tailcfg.MapResponse{
+				Node: &tailcfg.Node{ID: 1, Name: "self-renamed"},
+				DNSConfig: &tailcfg.DNSConfig{
+					Resolvers: []*dnstype.Resolver{{Addr: "9.9.9.9"}},
+				},
+			},
+		},
+		{
+			name: "client-outdated",
+			full: full(func(resp *tailcfg.MapResponse) {
+				resp.ClientVersion = &tailcfg.ClientVersion{LatestVersion: "1.76.0"}
+			}),
+			want: &tailcfg.MapResponse{
+				ClientVersion: &tailcfg.ClientVersion{LatestVersion: "1.76.0"},
+			},
+		},
+		{
+			name: "health-changed",
+			full: full(func(resp *tailcfg.MapResponse) {
+				resp.Health = []string{"This node is advertised as an exit node, but it has not been approved."}
+			}),
+			want: &tailcfg.MapResponse{
+				Health: []string{"This node is advertised as an exit node, but it has not been approved."},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var state mapState
+			state.reset(full(nil))
+
+			got := state.diff(tt.full)
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateComparable(netip.Prefix{})); diff != "" {
+				t.Errorf("diff() unexpected result (-want +got):\n%s", diff)
+			}
+
+			// Once applied, the state is the full map response.
+			if got != nil {
+				state.apply(got)
+			}
+			if resp := state.diff(tt.full); resp != nil {
+				t.Errorf("diff() after apply = %v, want nil", resp)
+			}
+		})
+	}
+}
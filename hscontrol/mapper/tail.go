@@ -114,14 +114,19 @@ func tailNode(
 		Expired:           node.IsExpired(),
 	}
 
-	tNode.CapMap = tailcfg.NodeCapMap{
+	capMap := tailcfg.NodeCapMap{
 		tailcfg.CapabilityFileSharing: []tailcfg.RawMessage{},
 		tailcfg.CapabilityAdmin:       []tailcfg.RawMessage{},
 		tailcfg.CapabilitySSH:         []tailcfg.RawMessage{},
 	}
 
 	if cfg.RandomizeClientPort {
-		tNode.CapMap[tailcfg.NodeAttrRandomizeClientPort] = []tailcfg.RawMessage{}
+		capMap[tailcfg.NodeAttrRandomizeClientPort] = []tailcfg.RawMessage{}
+	}
+
+	tNode.CapMap, err = pol.CompileNodeCapMap(node, capMap)
+	if err != nil {
+		return nil, fmt.Errorf("tailNode, failed to compile node attributes: %w", err)
 	}
 
 	if node.IsOnline == nil || !*node.IsOnline {
@@ -177,6 +177,44 @@ func TestTailNode(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "node-attributes",
+			node: &types.Node{
+				GivenName: "attrs",
+				IPv4:      iap("100.64.0.1"),
+				User:      types.User{Name: "user1"},
+				Hostinfo:  &tailcfg.Hostinfo{},
+			},
+			pol: &policy.ACLPolicy{
+				NodeAttrs: []policy.NodeAttr{
+					{
+						Targets:          []string{"user1"},
+						Attributes:       []string{"nextdns:abc123"},
+						RemoveAttributes: []string{string(tailcfg.CapabilityFileSharing)},
+					},
+				},
+			},
+			dnsConfig:  &tailcfg.DNSConfig{},
+			baseDomain: "",
+			want: &tailcfg.Node{
+				Name:              "attrs",
+				StableID:          "0",
+				Addresses:         []netip.Prefix{netip.MustParsePrefix("100.64.0.1/32")},
+				AllowedIPs:        []netip.Prefix{netip.MustParsePrefix("100.64.0.1/32")},
+				DERP:              "127.3.3.40:0",
+				Hostinfo:          hiview(tailcfg.Hostinfo{}),
+				Tags:              []string{},
+				PrimaryRoutes:     []netip.Prefix{},
+				MachineAuthorized: true,
+
+				CapMap: tailcfg.NodeCapMap{
+					tailcfg.CapabilityAdmin: []tailcfg.RawMessage{},
+					tailcfg.CapabilitySSH:   []tailcfg.RawMessage{},
+					"nextdns:abc123":        []tailcfg.RawMessage{},
+				},
+			},
+			wantErr: false,
+		},
 		// TODO: Add tests to check other aspects of the node conversion:
 		// - With tags and policy
 		// - dnsconfig and basedomain
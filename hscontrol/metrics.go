@@ -57,6 +57,16 @@ var (
 		Name:      "mapresponse_closed_total",
 		Help:      "total count of calls to mapresponse close",
 	}, []string{"return"})
+	policyLastLoadSeconds = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: prometheusNamespace,
+		Name:      "policy_last_successful_load_timestamp_seconds",
+		Help:      "time of the last successful load of the policy",
+	})
+	policyInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: prometheusNamespace,
+		Name:      "policy_info",
+		Help:      "sha256 hash of the last successfully loaded policy",
+	}, []string{"hash"})
 	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: prometheusNamespace,
 		Name:      "http_duration_seconds",
@@ -99,6 +99,12 @@ func (h *Headscale) NoiseUpgradeHandler(
 	router.HandleFunc("/machine/register", noiseServer.NoiseRegistrationHandler).
 		Methods(http.MethodPost)
 	router.HandleFunc("/machine/map", noiseServer.NoisePollNetMapHandler)
+	router.HandleFunc("/machine/ssh/action/from/{src_node_id}/to/{dst_node_id}", noiseServer.SSHActionHandler).
+		Methods(http.MethodGet)
+	router.HandleFunc("/machine/ssh/wait/{check_id}", noiseServer.SSHWaitHandler).
+		Methods(http.MethodGet)
+	router.HandleFunc("/machine/ping/{ping_id}", noiseServer.PingResponseHandler).
+		Methods(http.MethodPost)
 
 	noiseServer.httpBaseConfig = &http.Server{
 		Handler:           router,
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/juanfont/headscale/hscontrol/types"
@@ -37,6 +38,9 @@ type Notifier struct {
 	b         *batcher
 	cfg       *types.Config
 	closed    bool
+
+	// nodesChanged is called by NodesChanged, see OnNodesChanged.
+	nodesChanged atomic.Pointer[func()]
 }
 
 func NewNotifier(cfg *types.Config) *Notifier {
@@ -74,6 +78,22 @@ func (n *Notifier) tracef(nID types.NodeID, msg string, args ...any) {
 		Int("open_chans", len(n.nodes)).Msgf(msg, args...)
 }
 
+// OnNodesChanged sets the function called by NodesChanged.
+func (n *Notifier) OnNodesChanged(f func()) {
+	n.nodesChanged.Store(&f)
+}
+
+// NodesChanged must be called after the nodes changed in a way that can
+// change the compiled policy, before the nodes are notified: nodes were
+// registered or deleted, or their addresses, name, user, tags, enabled
+// routes, key expiry or the Hostinfo used by postures changed. Updates of
+// the endpoints, DERP region or online status of a node do not need it.
+func (n *Notifier) NodesChanged() {
+	if f := n.nodesChanged.Load(); f != nil {
+		(*f)()
+	}
+}
+
 func (n *Notifier) AddNode(nodeID types.NodeID, c chan<- types.StateUpdate) {
 	start := time.Now()
 	notifierWaitersForLock.WithLabelValues("lock", "add").Inc()
@@ -263,3 +263,51 @@ func TestBatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestOnNodesChanged(t *testing.T) {
+	expiry := time.Now()
+
+	// Notifying an update never drops the compiled policy, the mutations
+	// the policy depends on call NodesChanged.
+	updates := []types.StateUpdate{
+		{Type: types.StateFullUpdate},
+		{Type: types.StatePeerChanged, ChangeNodes: []types.NodeID{2}},
+		{Type: types.StatePeerRemoved, Removed: []types.NodeID{2}},
+		{
+			Type: types.StatePeerChangedPatch,
+			ChangePatches: []*tailcfg.PeerChange{
+				{NodeID: 2, DERPRegion: 6},
+				{NodeID: 2, KeyExpiry: &expiry},
+			},
+		},
+		{Type: types.StateDERPUpdated},
+	}
+
+	n := NewNotifier(&types.Config{
+		Tuning: types.Tuning{
+			BatchChangeDelay:    time.Hour,
+			NotifierSendTimeout: time.Second,
+		},
+	})
+
+	// Without a function set, NodesChanged does nothing.
+	n.NodesChanged()
+
+	var got int
+	n.OnNodesChanged(func() { got++ })
+
+	for _, update := range updates {
+		n.NotifyAll(context.Background(), update)
+		n.NotifyByNodeID(context.Background(), update, 1)
+	}
+
+	if got != 0 {
+		t.Errorf("OnNodesChanged() called %d times by notifications, want 0", got)
+	}
+
+	n.NodesChanged()
+
+	if got != 1 {
+		t.Errorf("OnNodesChanged() called %d times, want 1", got)
+	}
+}
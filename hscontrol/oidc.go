@@ -44,6 +44,9 @@ var (
 		"requested node state key expired before authorisation completed",
 	)
 	errOIDCNodeKeyMissing = errors.New("could not get node key from cache")
+	errOIDCSSHCheckUser   = errors.New(
+		"authenticated principal is not the user of the source node of the SSH connection",
+	)
 )
 
 type AuthProviderOIDC struct {
@@ -54,6 +57,14 @@ type AuthProviderOIDC struct {
 	notifier          *notifier.Notifier
 	ipAlloc           *db.IPAllocator
 
+	// expiryUpdate returns the update sent to the peers of a node whose
+	// key expiry changed.
+	expiryUpdate func(types.StateUpdate) types.StateUpdate
+
+	sshChecks *sshChecks
+	// sshCheckCache maps the OIDC state to the SSH check being approved.
+	sshCheckCache *zcache.Cache[string, string]
+
 	oidcProvider *oidc.Provider
 	oauth2Config *oauth2.Config
 }
@@ -65,6 +76,8 @@ func NewAuthProviderOIDC(
 	db *db.HSDatabase,
 	notif *notifier.Notifier,
 	ipAlloc *db.IPAllocator,
+	expiryUpdate func(types.StateUpdate) types.StateUpdate,
+	sshChecks *sshChecks,
 ) (*AuthProviderOIDC, error) {
 	var err error
 	// grab oidc config if it hasn't been already
@@ -96,6 +109,13 @@ func NewAuthProviderOIDC(
 		registrationCache: registrationCache,
 		notifier:          notif,
 		ipAlloc:           ipAlloc,
+		expiryUpdate:      expiryUpdate,
+
+		sshChecks: sshChecks,
+		sshCheckCache: zcache.New[string, string](
+			registerCacheExpiration,
+			registerCacheCleanup,
+		),
 
 		oidcProvider: oidcProvider,
 		oauth2Config: oauth2Config,
@@ -109,6 +129,10 @@ func (a *AuthProviderOIDC) AuthURL(mKey key.MachinePublic) string {
 		mKey.String())
 }
 
+func (a *AuthProviderOIDC) SSHCheckURL(checkID string) string {
+	return sshCheckURL(a.serverURL, checkID)
+}
+
 func (a *AuthProviderOIDC) determineNodeExpiry(idTokenExpiration time.Time) time.Time {
 	if a.cfg.UseExpiryFromToken {
 		return idTokenExpiration
@@ -172,6 +196,96 @@ func (a *AuthProviderOIDC) RegisterHandler(
 	http.Redirect(writer, req, authURL, http.StatusFound)
 }
 
+// SSHCheckHandler redirects to the OIDC provider for re-authentication
+// before approving an SSH check. Puts the check ID in cache so the
+// callback can retrieve it using the oidc state param.
+// Listens in /ssh/check/:check_id.
+func (a *AuthProviderOIDC) SSHCheckHandler(
+	writer http.ResponseWriter,
+	req *http.Request,
+) {
+	check, err := a.sshChecks.get(mux.Vars(req)["check_id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusNotFound)
+
+		return
+	}
+
+	randomBlob := make([]byte, randomByteSize)
+	if _, err := rand.Read(randomBlob); err != nil {
+		http.Error(writer, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	stateStr := hex.EncodeToString(randomBlob)[:32]
+	a.sshCheckCache.Set(stateStr, check.ID)
+
+	extras := make([]oauth2.AuthCodeOption, 0, len(a.cfg.ExtraParams)+1)
+	for k, v := range a.cfg.ExtraParams {
+		extras = append(extras, oauth2.SetAuthURLParam(k, v))
+	}
+
+	// The user has to authenticate again, even if the OIDC provider
+	// still has a session.
+	extras = append(extras, oauth2.SetAuthURLParam("prompt", "login"))
+
+	authURL := a.oauth2Config.AuthCodeURL(stateStr, extras...)
+	log.Debug().Msgf("Redirecting to %s for SSH check", authURL)
+
+	http.Redirect(writer, req, authURL, http.StatusFound)
+}
+
+// approveSSHCheck approves the SSH check if the authenticated user is
+// the user of the source node.
+func (a *AuthProviderOIDC) approveSSHCheck(
+	writer http.ResponseWriter,
+	user *types.User,
+	checkID string,
+) {
+	check, err := a.sshChecks.get(checkID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	src, err := a.db.GetNodeByID(check.SrcNodeID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if src.UserID != user.ID {
+		http.Error(writer, errOIDCSSHCheckUser.Error(), http.StatusForbidden)
+		return
+	}
+
+	if _, err := a.sshChecks.approve(check.ID); err != nil {
+		http.Error(writer, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	log.Info().
+		Str("check", check.ID).
+		Str("user", user.Username()).
+		Str("src", src.Hostname).
+		Msg("SSH check approved")
+
+	var content bytes.Buffer
+	if err := oidcCallbackTemplate.Execute(&content, oidcCallbackTemplateConfig{
+		User: user.DisplayNameOrUsername(),
+		Verb: "SSH connection approved",
+	}); err != nil {
+		http.Error(writer, fmt.Errorf("rendering OIDC callback template: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	writer.WriteHeader(http.StatusOK)
+	if _, err := writer.Write(content.Bytes()); err != nil {
+		util.LogErr(err, "Failed to write response")
+	}
+}
+
 type oidcCallbackTemplateConfig struct {
 	User string
 	Verb string
@@ -233,6 +347,13 @@ func (a *AuthProviderOIDC) OIDCCallbackHandler(
 		return
 	}
 
+	if checkID, ok := a.sshCheckCache.Get(state); ok {
+		a.sshCheckCache.Delete(state)
+		a.approveSSHCheck(writer, user, checkID)
+
+		return
+	}
+
 	// Retrieve the node and the machine key from the state cache and
 	// database.
 	// If the node exists, then the node should be reauthenticated,
@@ -415,6 +536,8 @@ func (a *AuthProviderOIDC) reauthenticateNode(
 		return err
 	}
 
+	a.notifier.NodesChanged()
+
 	ctx := types.NotifyCtx(context.Background(), "oidc-expiry-self", node.Hostname)
 	a.notifier.NotifyByNodeID(
 		ctx,
@@ -426,7 +549,7 @@ func (a *AuthProviderOIDC) reauthenticateNode(
 	)
 
 	ctx = types.NotifyCtx(context.Background(), "oidc-expiry-peers", node.Hostname)
-	a.notifier.NotifyWithIgnore(ctx, types.StateUpdateExpire(node.ID, expiry), node.ID)
+	a.notifier.NotifyWithIgnore(ctx, a.expiryUpdate(types.StateUpdateExpire(node.ID, expiry)), node.ID)
 
 	return nil
 }
@@ -455,12 +578,31 @@ func (a *AuthProviderOIDC) createOrUpdateUserFromClaim(
 		}
 	}
 
+	existing := user.ID != 0
+	groups := user.Groups
+
 	user.FromClaim(claims)
 	err = a.db.DB.Save(user).Error
 	if err != nil {
 		return nil, fmt.Errorf("creating or updating user: %w", err)
 	}
 
+	// Groups can be used in the policy, so a change in membership
+	// can change the packet filter of any node.
+	if existing && !slices.Equal(groups, user.Groups) {
+		log.Info().
+			Str("user", user.Username()).
+			Strs("groups", user.Groups).
+			Msg("OIDC group membership changed, updating nodes")
+
+		a.notifier.NodesChanged()
+
+		ctx := types.NotifyCtx(context.Background(), "oidc-groups-changed", user.Username())
+		a.notifier.NotifyAll(ctx, types.StateUpdate{
+			Type: types.StateFullUpdate,
+		})
+	}
+
 	return user, nil
 }
 
@@ -484,6 +626,8 @@ func (a *AuthProviderOIDC) registerNode(
 		return fmt.Errorf("could not register node: %w", err)
 	}
 
+	a.notifier.NodesChanged()
+
 	return nil
 }
 
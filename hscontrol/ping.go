@@ -0,0 +1,269 @@
+package hscontrol
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/rs/zerolog/log"
+	"tailscale.com/tailcfg"
+)
+
+const (
+	// pingTimeout is how long to wait for a node to answer a ping,
+	// Tailscale clients give up on disco and TSMP pings after 10
+	// seconds.
+	pingTimeout = 15 * time.Second
+
+	pingIDLength        = 32
+	pingPathPrefix      = "https://unused/machine/ping/"
+	maxPingResponseSize = 64 << 10
+
+	PingTypeC2N = "c2n"
+)
+
+var (
+	ErrNodeNotConnected = errors.New("node is not connected")
+	ErrPingTimeout      = errors.New("node did not answer the ping in time")
+	ErrInvalidPingType  = errors.New("invalid ping type")
+	ErrPingTarget       = errors.New("disco and TSMP pings require a target")
+	errPingNotFound     = errors.New("ping not found or expired")
+)
+
+// pendingPing is a PingRequest sent to a node, waiting for its answer.
+type pendingPing struct {
+	id     string
+	nodeID types.NodeID
+	typ    string
+	sent   time.Time
+
+	answer chan *tailcfg.PingResponse
+}
+
+// pendingPings tracks the PingRequests sent to nodes.
+type pendingPings struct {
+	mu    sync.Mutex
+	pings map[string]*pendingPing
+}
+
+func newPendingPings() *pendingPings {
+	return &pendingPings{
+		pings: make(map[string]*pendingPing),
+	}
+}
+
+func (p *pendingPings) start(nodeID types.NodeID, typ string) (*pendingPing, error) {
+	randomBlob := make([]byte, randomByteSize)
+	if _, err := rand.Read(randomBlob); err != nil {
+		return nil, err
+	}
+
+	ping := &pendingPing{
+		id:     hex.EncodeToString(randomBlob)[:pingIDLength],
+		nodeID: nodeID,
+		typ:    typ,
+		sent:   time.Now(),
+		answer: make(chan *tailcfg.PingResponse, 1),
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pings[ping.id] = ping
+
+	return ping, nil
+}
+
+func (p *pendingPings) get(id string) (*pendingPing, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ping, ok := p.pings[id]
+
+	return ping, ok
+}
+
+func (p *pendingPings) done(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.pings, id)
+}
+
+// pingRequest returns the PingRequest asking the node to answer the ping
+// on the Noise endpoint. A c2n ping asks the node to echo the ID of the
+// ping.
+func (ping *pendingPing) pingRequest(target netip.Addr) (*tailcfg.PingRequest, error) {
+	req := &tailcfg.PingRequest{
+		URL:        pingPathPrefix + ping.id,
+		URLIsNoise: true,
+		Types:      ping.typ,
+		IP:         target,
+	}
+
+	if ping.typ == PingTypeC2N {
+		echo, err := http.NewRequest(http.MethodPost, "/echo", bytes.NewBufferString(ping.id))
+		if err != nil {
+			return nil, err
+		}
+
+		var payload bytes.Buffer
+		if err := echo.Write(&payload); err != nil {
+			return nil, err
+		}
+
+		req.Payload = payload.Bytes()
+	}
+
+	return req, nil
+}
+
+// parseAnswer returns the result of the ping from the answer of the node,
+// a tailcfg.PingResponse for disco and TSMP pings, or the HTTP response of
+// the c2n request. The latency of a c2n ping is the time it took the node
+// to answer through the control connection.
+func (ping *pendingPing) parseAnswer(body []byte) (*tailcfg.PingResponse, error) {
+	if ping.typ != PingTypeC2N {
+		var resp tailcfg.PingResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, err
+		}
+
+		return &resp, nil
+	}
+
+	resp := &tailcfg.PingResponse{
+		Type:           PingTypeC2N,
+		LatencySeconds: time.Since(ping.sent).Seconds(),
+	}
+
+	httpResp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(body)), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	echo, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case httpResp.StatusCode != http.StatusOK:
+		resp.Err = fmt.Sprintf("c2n request failed: %s", httpResp.Status)
+	case string(echo) != ping.id:
+		resp.Err = "c2n echo does not match the request"
+	}
+
+	return resp, nil
+}
+
+// pingNode sends a PingRequest to the node and waits for its answer. A c2n
+// ping checks that the node answers on its control connection, disco and
+// TSMP pings ask the node to ping the target.
+func (h *Headscale) pingNode(
+	ctx context.Context,
+	node *types.Node,
+	typ string,
+	target netip.Addr,
+) (*tailcfg.PingResponse, error) {
+	switch typ {
+	case PingTypeC2N:
+	case string(tailcfg.PingDisco), string(tailcfg.PingTSMP):
+		if !target.IsValid() {
+			return nil, ErrPingTarget
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPingType, typ)
+	}
+
+	if !h.nodeNotifier.IsLikelyConnected(node.ID) {
+		return nil, ErrNodeNotConnected
+	}
+
+	ping, err := h.pings.start(node.ID, typ)
+	if err != nil {
+		return nil, err
+	}
+	defer h.pings.done(ping.id)
+
+	pingRequest, err := ping.pingRequest(target)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyCtx := types.NotifyCtx(ctx, "ping-node", node.Hostname)
+	h.nodeNotifier.NotifyByNodeID(notifyCtx, types.StateUpdate{
+		Type:        types.StatePingRequest,
+		PingRequest: pingRequest,
+	}, node.ID)
+
+	timer := time.NewTimer(pingTimeout)
+	defer timer.Stop()
+
+	select {
+	case resp := <-ping.answer:
+		return resp, nil
+	case <-timer.C:
+		return nil, ErrPingTimeout
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// PingResponseHandler receives the answer of a node to a PingRequest, see
+// pingNode. Only the node the ping was sent to can answer it.
+// Listens in /machine/ping/{ping_id}.
+func (ns *noiseServer) PingResponseHandler(
+	writer http.ResponseWriter,
+	req *http.Request,
+) {
+	ping, ok := ns.headscale.pings.get(mux.Vars(req)["ping_id"])
+	if !ok {
+		http.Error(writer, errPingNotFound.Error(), http.StatusNotFound)
+
+		return
+	}
+
+	node, err := ns.headscale.db.GetNodeByMachineKey(ns.machineKey)
+	if err != nil || node.ID != ping.nodeID {
+		http.Error(writer, "ping belongs to another node", http.StatusForbidden)
+
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxPingResponseSize))
+	if err != nil {
+		http.Error(writer, "", http.StatusBadRequest)
+
+		return
+	}
+
+	resp, err := ping.parseAnswer(body)
+	if err != nil {
+		log.Debug().Err(err).Str("node", node.Hostname).Msg("Invalid ping answer")
+		http.Error(writer, "invalid ping answer", http.StatusBadRequest)
+
+		return
+	}
+
+	// Only the first answer is kept, nodes may answer a ping with
+	// several types more than once.
+	select {
+	case ping.answer <- resp:
+	default:
+	}
+
+	writer.WriteHeader(http.StatusOK)
+}
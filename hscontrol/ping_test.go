@@ -0,0 +1,135 @@
+package hscontrol
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"gopkg.in/check.v1"
+	"tailscale.com/tailcfg"
+	"tailscale.com/types/key"
+)
+
+func (s *Suite) TestPingNode(c *check.C) {
+	user, err := app.db.CreateUser("test")
+	c.Assert(err, check.IsNil)
+
+	machineKey := key.NewMachine().Public()
+	node := types.Node{Hostname: "laptop", MachineKey: machineKey, UserID: user.ID}
+	c.Assert(app.db.DB.Save(&node).Error, check.IsNil)
+
+	target := netip.MustParseAddr("100.64.0.2")
+
+	_, err = app.pingNode(context.Background(), &node, "icmp", target)
+	c.Assert(err, check.ErrorMatches, "invalid ping type.*")
+
+	_, err = app.pingNode(context.Background(), &node, string(tailcfg.PingDisco), netip.Addr{})
+	c.Assert(err, check.Equals, ErrPingTarget)
+
+	_, err = app.pingNode(context.Background(), &node, PingTypeC2N, netip.Addr{})
+	c.Assert(err, check.Equals, ErrNodeNotConnected)
+
+	updates := make(chan types.StateUpdate, 1)
+	app.nodeNotifier.AddNode(node.ID, updates)
+	defer app.nodeNotifier.RemoveNode(node.ID, updates)
+
+	// answer answers the PingRequest of the next update as the node would,
+	// and returns the status of the answer.
+	answer := func(ns *noiseServer, answer func(*tailcfg.PingRequest) []byte) int {
+		update := <-updates
+		c.Assert(update.Type, check.Equals, types.StatePingRequest)
+		c.Assert(update.PingRequest.URLIsNoise, check.Equals, true)
+
+		url := strings.TrimPrefix(update.PingRequest.URL, "https://unused")
+		req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(answer(update.PingRequest)))
+		rec := httptest.NewRecorder()
+
+		router := mux.NewRouter()
+		router.HandleFunc("/machine/ping/{ping_id}", ns.PingResponseHandler)
+		router.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	echo := func(pingRequest *tailcfg.PingRequest) []byte {
+		req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(pingRequest.Payload)))
+		c.Assert(err, check.IsNil)
+		c.Assert(req.URL.Path, check.Equals, "/echo")
+
+		body, err := io.ReadAll(req.Body)
+		c.Assert(err, check.IsNil)
+
+		resp := &http.Response{
+			StatusCode:    http.StatusOK,
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			ContentLength: int64(len(body)),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+		}
+
+		var buf bytes.Buffer
+		c.Assert(resp.Write(&buf), check.IsNil)
+
+		return buf.Bytes()
+	}
+
+	// A c2n ping is answered with the echo of the request.
+	done := make(chan int)
+	go func() {
+		done <- answer(&noiseServer{headscale: app, machineKey: machineKey}, echo)
+	}()
+
+	resp, err := app.pingNode(context.Background(), &node, PingTypeC2N, netip.Addr{})
+	c.Assert(err, check.IsNil)
+	c.Assert(<-done, check.Equals, http.StatusOK)
+	c.Assert(string(resp.Type), check.Equals, PingTypeC2N)
+	c.Assert(resp.Err, check.Equals, "")
+	c.Assert(resp.LatencySeconds > 0, check.Equals, true)
+
+	// Another node cannot answer the ping.
+	go func() {
+		done <- answer(&noiseServer{headscale: app, machineKey: key.NewMachine().Public()}, echo)
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-done
+		cancel()
+	}()
+
+	_, err = app.pingNode(ctx, &node, PingTypeC2N, netip.Addr{})
+	c.Assert(err, check.Equals, context.Canceled)
+
+	// A disco ping is answered with the result of the ping of the target.
+	go func() {
+		done <- answer(&noiseServer{headscale: app, machineKey: machineKey}, func(pingRequest *tailcfg.PingRequest) []byte {
+			c.Assert(pingRequest.Types, check.Equals, "disco")
+			c.Assert(pingRequest.IP, check.Equals, target)
+
+			body, err := json.Marshal(tailcfg.PingResponse{
+				Type:           tailcfg.PingDisco,
+				IP:             target.String(),
+				LatencySeconds: 0.012,
+				DERPRegionID:   1,
+				DERPRegionCode: "ams",
+			})
+			c.Assert(err, check.IsNil)
+
+			return body
+		})
+	}()
+
+	resp, err = app.pingNode(context.Background(), &node, string(tailcfg.PingDisco), target)
+	c.Assert(err, check.IsNil)
+	c.Assert(<-done, check.Equals, http.StatusOK)
+	c.Assert(resp.LatencySeconds, check.Equals, 0.012)
+	c.Assert(resp.DERPRegionCode, check.Equals, "ams")
+}
@@ -0,0 +1,115 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	"github.com/juanfont/headscale/hscontrol/policy/matcher"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"tailscale.com/tailcfg"
+)
+
+var ErrInvalidAccessGrant = errors.New("invalid access grant")
+
+// WithAccessGrants returns a copy of the policy with the access grants as
+// its AccessGrants, replacing the previous ones. A nil policy allows all
+// traffic, and stays nil.
+func (pol *ACLPolicy) WithAccessGrants(grants []types.AccessGrant) *ACLPolicy {
+	if pol == nil {
+		return nil
+	}
+
+	ret := *pol
+	ret.AccessGrants = make([]AccessGrant, 0, len(grants))
+	for _, grant := range grants {
+		ret.AccessGrants = append(ret.AccessGrants, AccessGrant{
+			ID:  grant.ID,
+			ACL: AccessGrantACL(grant),
+		})
+	}
+
+	return &ret
+}
+
+// AccessGrantACL returns the acls rule of an access grant, each
+// destination gets the ports of the grant.
+func AccessGrantACL(grant types.AccessGrant) ACL {
+	acl := ACL{
+		Action:   "accept",
+		Protocol: grant.Protocol,
+		Sources:  grant.Sources,
+	}
+
+	for _, dest := range grant.Destinations {
+		acl.Destinations = append(acl.Destinations, dest+":"+grant.Ports)
+	}
+
+	return acl
+}
+
+// ValidateAccessGrant checks that the access grant can be compiled with
+// the policy and the nodes, the same way as when the policy is compiled.
+func (pol *ACLPolicy) ValidateAccessGrant(grant types.AccessGrant, nodes types.Nodes) error {
+	if len(grant.Sources) == 0 || len(grant.Destinations) == 0 {
+		return fmt.Errorf("%w: sources and destinations are required", ErrInvalidAccessGrant)
+	}
+
+	if _, err := pol.compileAccessGrant(AccessGrantACL(grant), nil, nodes); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidAccessGrant, err)
+	}
+
+	return nil
+}
+
+// compileAccessGrant compiles the acls rule of an access grant with the
+// groups, hosts and tags of the policy, see compileFilterRules.
+func (pol *ACLPolicy) compileAccessGrant(
+	acl ACL,
+	node *types.Node,
+	nodes types.Nodes,
+) ([]tailcfg.FilterRule, error) {
+	single := ACLPolicy{}
+	if pol != nil {
+		single = *pol
+	}
+	single.ACLs = []ACL{acl}
+	single.Grants = nil
+	single.AccessGrants = nil
+
+	return single.compileFilterRules(node, nodes)
+}
+
+// AccessGrantNodes returns the nodes that are a source or a destination
+// of the access grants, the only nodes whose peers or packet filter
+// change when the grants are added or removed. Grants that cannot be
+// compiled are left out, as they are left out of the policy.
+func (pol *ACLPolicy) AccessGrantNodes(grants []types.AccessGrant, nodes types.Nodes) types.Nodes {
+	var matches []matcher.Match
+	for _, grant := range grants {
+		rules, err := pol.compileAccessGrant(AccessGrantACL(grant), nil, nodes)
+		if err != nil {
+			continue
+		}
+
+		for _, rule := range rules {
+			matches = append(matches, matcher.MatchFromFilterRule(rule))
+		}
+	}
+
+	var ret types.Nodes
+	for _, node := range nodes {
+		if slices.ContainsFunc(matches, func(match matcher.Match) bool {
+			return match.SrcsContainsIPs(node.IPs()) ||
+				match.DestsContainsIP(node.IPs()) ||
+				slices.ContainsFunc(node.Routes, func(route types.Route) bool {
+					return route.Enabled && match.Dests.OverlapsPrefix(netip.Prefix(route.Prefix))
+				})
+		}) {
+			ret = append(ret, node)
+		}
+	}
+
+	return ret
+}
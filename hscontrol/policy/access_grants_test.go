@@ -0,0 +1,243 @@
+package policy
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tailscale.com/tailcfg"
+)
+
+func TestAccessGrants(t *testing.T) {
+	nodes := engineTestNodes()[:3]
+	pol := &ACLPolicy{
+		TagOwners: TagOwners{
+			"tag:server": {"user2"},
+		},
+		ACLs: []ACL{
+			{
+				Action:       "accept",
+				Sources:      []string{"user1"},
+				Destinations: []string{"user1:*"},
+			},
+		},
+	}
+
+	grants := []types.AccessGrant{
+		{
+			ID:           7,
+			Sources:      []string{"user1"},
+			Destinations: []string{"tag:server"},
+			Ports:        "22",
+			Protocol:     "tcp",
+		},
+		// References a tag that is not in the policy, and is left out.
+		{
+			ID:           8,
+			Sources:      []string{"user1"},
+			Destinations: []string{"tag:removed"},
+			Ports:        "*",
+		},
+	}
+
+	assert.Nil(t, (*ACLPolicy)(nil).WithAccessGrants(grants))
+
+	withGrants := pol.WithAccessGrants(grants)
+	assert.Empty(t, pol.AccessGrants, "the policy must not be modified")
+
+	rules, err := withGrants.CompileFilterRules(nodes)
+	require.NoError(t, err)
+
+	want := []tailcfg.FilterRule{
+		{
+			SrcIPs: []string{"100.64.0.1/32", "100.64.0.2/32", "fd7a:115c:a1e0::1/128"},
+			DstPorts: []tailcfg.NetPortRange{
+				{IP: "100.64.0.1/32", Ports: tailcfg.PortRangeAny},
+				{IP: "100.64.0.2/32", Ports: tailcfg.PortRangeAny},
+				{IP: "fd7a:115c:a1e0::1/128", Ports: tailcfg.PortRangeAny},
+			},
+		},
+		{
+			SrcIPs: []string{"100.64.0.1/32", "100.64.0.2/32", "fd7a:115c:a1e0::1/128"},
+			DstPorts: []tailcfg.NetPortRange{
+				{IP: "100.64.0.3/32", Ports: tailcfg.PortRange{First: 22, Last: 22}},
+			},
+			IPProto: []int{protocolTCP},
+		},
+	}
+	if diff := cmp.Diff(want, rules); diff != "" {
+		t.Errorf("CompileFilterRules() unexpected result (-want +got):\n%s", diff)
+	}
+
+	compiled, err := NewEngine().Compile(withGrants, nodes, 1)
+	require.NoError(t, err)
+
+	check, err := compiled.CheckAccess(
+		netip.MustParseAddr("100.64.0.2"),
+		netip.MustParseAddr("100.64.0.3"),
+		22,
+		"tcp",
+	)
+	require.NoError(t, err)
+	assert.True(t, check.Allowed)
+
+	wantMatches := []AccessMatch{
+		{
+			Section:      "accessGrants",
+			Index:        7,
+			Sources:      []string{"user1"},
+			Destinations: []string{"tag:server:22"},
+		},
+	}
+	if diff := cmp.Diff(wantMatches, check.Matches); diff != "" {
+		t.Errorf("CheckAccess() unexpected matches (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateAccessGrant(t *testing.T) {
+	nodes := engineTestNodes()[:3]
+	pol := &ACLPolicy{
+		TagOwners: TagOwners{
+			"tag:server": {"user2"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		pol     *ACLPolicy
+		grant   types.AccessGrant
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			pol:  pol,
+			grant: types.AccessGrant{
+				Sources:      []string{"user1"},
+				Destinations: []string{"tag:server"},
+				Ports:        "22,443",
+			},
+		},
+		{
+			name: "nil-policy",
+			pol:  nil,
+			grant: types.AccessGrant{
+				Sources:      []string{"*"},
+				Destinations: []string{"100.64.0.3"},
+				Ports:        "*",
+			},
+		},
+		{
+			name: "no-sources",
+			pol:  pol,
+			grant: types.AccessGrant{
+				Destinations: []string{"tag:server"},
+				Ports:        "22",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown-tag",
+			pol:  pol,
+			grant: types.AccessGrant{
+				Sources:      []string{"user1"},
+				Destinations: []string{"tag:unknown"},
+				Ports:        "22",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-ports",
+			pol:  pol,
+			grant: types.AccessGrant{
+				Sources:      []string{"user1"},
+				Destinations: []string{"tag:server"},
+				Ports:        "ssh",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pol.ValidateAccessGrant(tt.grant, nodes)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAccessGrant)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccessGrantNodes(t *testing.T) {
+	nodes := engineTestNodes()
+	pol := &ACLPolicy{
+		TagOwners: TagOwners{
+			"tag:server": {"user2"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		grants []types.AccessGrant
+		want   []types.NodeID
+	}{
+		{
+			name: "user-to-tag",
+			grants: []types.AccessGrant{
+				{
+					Sources:      []string{"user1"},
+					Destinations: []string{"tag:server"},
+					Ports:        "22",
+				},
+			},
+			want: []types.NodeID{1, 2, 3},
+		},
+		{
+			name: "part-of-subnet-route",
+			grants: []types.AccessGrant{
+				{
+					Sources:      []string{"100.64.0.5"},
+					Destinations: []string{"10.1.0.0/16"},
+					Ports:        "*",
+				},
+			},
+			want: []types.NodeID{4, 5},
+		},
+		{
+			name: "invalid-grant-left-out",
+			grants: []types.AccessGrant{
+				{
+					Sources:      []string{"user1"},
+					Destinations: []string{"tag:removed"},
+					Ports:        "*",
+				},
+				{
+					Sources:      []string{"user4"},
+					Destinations: []string{"tag:server"},
+					Ports:        "443",
+				},
+			},
+			want: []types.NodeID{3, 5},
+		},
+		{
+			name: "no-grants",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []types.NodeID
+			for _, node := range pol.AccessGrantNodes(tt.grants, nodes) {
+				got = append(got, node.ID)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("AccessGrantNodes() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
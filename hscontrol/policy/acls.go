@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/netip"
+	"net/url"
 	"os"
 	"slices"
 	"strconv"
@@ -28,6 +29,11 @@ var (
 	ErrInvalidTag        = errors.New("invalid tag")
 	ErrInvalidPortFormat = errors.New("invalid port format")
 	ErrWildcardIsNeeded  = errors.New("wildcard as port is required for the protocol")
+	ErrAutoGroupSelf     = errors.New("autogroup:self can only be used as a destination")
+	ErrInvalidGrant      = errors.New("grant must have at least one of ip or app")
+
+	ErrInvalidCheckPeriod = errors.New("check period must be positive or \"always\"")
+	ErrRecorderRequired   = errors.New("enforceRecorder requires a recorder")
 )
 
 const (
@@ -36,6 +42,35 @@ const (
 	expectedTokenItems = 2
 )
 
+const (
+	autoGroupMember  = "autogroup:member"
+	autoGroupTagged  = "autogroup:tagged"
+	autoGroupNonRoot = "autogroup:nonroot"
+)
+
+const (
+	// defaultSSHCheckPeriod is how long a check of an SSH rule is
+	// remembered when the rule does not set a checkPeriod.
+	defaultSSHCheckPeriod = 12 * time.Hour
+	sshCheckAlways        = "always"
+)
+
+const (
+	// sshRecorderPort is the port session recorders listen on for
+	// recordings, at http://<addr>:<port>/record.
+	sshRecorderPort = 80
+
+	sshRecordingRejectMessage    = "# Session recording is required, but the recorder is not available."
+	sshRecordingTerminateMessage = "# Session recording failed, terminating the session."
+
+	sshCheckRecorderParam        = "recorder"
+	sshCheckEnforceRecorderParam = "enforce_recorder"
+)
+
+// oidcGroupPrefix is the prefix of groups that refer to a group of the
+// OIDC provider, as found in the groups claim of its users.
+const oidcGroupPrefix = "group:oidc:"
+
 var theInternetSet *netipx.IPSet
 
 // theInternet returns the IPSet for the Internet.
@@ -130,6 +165,19 @@ func LoadACLPolicyFromBytes(acl []byte) (*ACLPolicy, error) {
 		return nil, ErrEmptyPolicy
 	}
 
+	// OIDC groups are resolved from the groups claim of the users, a group
+	// of the policy with the same prefix would be ignored.
+	for group := range policy.Groups {
+		if isOIDCGroup(group) {
+			return nil, fmt.Errorf(
+				"%w: %q, the %s prefix is reserved for the groups of the OIDC provider",
+				ErrInvalidGroup,
+				group,
+				oidcGroupPrefix,
+			)
+		}
+	}
+
 	return &policy, nil
 }
 
@@ -143,7 +191,7 @@ func GenerateFilterAndSSHRulesForTests(
 		return tailcfg.FilterAllowAll, &tailcfg.SSHPolicy{}, nil
 	}
 
-	rules, err := policy.CompileFilterRules(append(peers, node))
+	rules, err := policy.CompileFilterRulesForNode(node, append(peers, node))
 	if err != nil {
 		return []tailcfg.FilterRule{}, &tailcfg.SSHPolicy{}, err
 	}
@@ -160,39 +208,91 @@ func GenerateFilterAndSSHRulesForTests(
 
 // CompileFilterRules takes a set of nodes and an ACLPolicy and generates a
 // set of Tailscale compatible FilterRules used to allow traffic on clients.
+// Destinations that depend on the node receiving the rules, like
+// autogroup:self, are expanded for every user.
 func (pol *ACLPolicy) CompileFilterRules(
 	nodes types.Nodes,
 ) ([]tailcfg.FilterRule, error) {
+	return pol.compileFilterRules(nil, nodes)
+}
+
+// CompileFilterRulesForNode generates the FilterRules as seen by the given
+// node. Destinations that depend on the node, like autogroup:self, are only
+// expanded for the node, the rest is identical to CompileFilterRules.
+func (pol *ACLPolicy) CompileFilterRulesForNode(
+	node *types.Node,
+	nodes types.Nodes,
+) ([]tailcfg.FilterRule, error) {
+	return pol.compileFilterRules(node, nodes)
+}
+
+// ruleOrigin is the entry of the policy a FilterRule is compiled from, see
+// AccessMatch.
+type ruleOrigin struct {
+	section string
+	index   int
+}
+
+func (pol *ACLPolicy) compileFilterRules(
+	node *types.Node,
+	nodes types.Nodes,
+) ([]tailcfg.FilterRule, error) {
+	rules, _, err := pol.compileFilterRulesWithOrigins(node, nodes)
+
+	return rules, err
+}
+
+// compileFilterRulesWithOrigins is compileFilterRules, also returning the
+// origin of every rule.
+func (pol *ACLPolicy) compileFilterRulesWithOrigins(
+	node *types.Node,
+	nodes types.Nodes,
+) ([]tailcfg.FilterRule, []ruleOrigin, error) {
 	if pol == nil {
-		return tailcfg.FilterAllowAll, nil
+		return tailcfg.FilterAllowAll, nil, nil
 	}
 
-	var rules []tailcfg.FilterRule
+	var (
+		rules   []tailcfg.FilterRule
+		origins []ruleOrigin
+	)
 
 	for index, acl := range pol.ACLs {
 		if acl.Action != "accept" {
-			return nil, ErrInvalidAction
+			return nil, nil, ErrInvalidAction
 		}
 
 		var srcIPs []string
 		for srcIndex, src := range acl.Sources {
 			srcs, err := pol.expandSource(src, nodes)
 			if err != nil {
-				return nil, fmt.Errorf("parsing policy, acl index: %d->%d: %w", index, srcIndex, err)
+				return nil, nil, fmt.Errorf("parsing policy, acl index: %d->%d: %w", index, srcIndex, err)
 			}
 			srcIPs = append(srcIPs, srcs...)
 		}
 
 		protocols, isWildcard, err := parseProtocol(acl.Protocol)
 		if err != nil {
-			return nil, fmt.Errorf("parsing policy, protocol err: %w ", err)
+			return nil, nil, fmt.Errorf("parsing policy, protocol err: %w ", err)
 		}
 
 		destPorts := []tailcfg.NetPortRange{}
+		var selfPorts []tailcfg.PortRange
 		for _, dest := range acl.Destinations {
 			alias, port, err := parseDestination(dest)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
+			}
+
+			if isAutoGroupSelf(alias) {
+				ports, err := expandPorts(port, isWildcard)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				selfPorts = append(selfPorts, *ports...)
+
+				continue
 			}
 
 			expanded, err := pol.ExpandAlias(
@@ -200,12 +300,12 @@ func (pol *ACLPolicy) CompileFilterRules(
 				alias,
 			)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			ports, err := expandPorts(port, isWildcard)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			var dests []tailcfg.NetPortRange
@@ -221,8 +321,97 @@ func (pol *ACLPolicy) CompileFilterRules(
 			destPorts = append(destPorts, dests...)
 		}
 
+		var aclRules []tailcfg.FilterRule
+		if len(destPorts) > 0 || len(selfPorts) == 0 {
+			aclRules = append(aclRules, tailcfg.FilterRule{
+				SrcIPs:   srcIPs,
+				DstPorts: destPorts,
+				IPProto:  protocols,
+			})
+		}
+
+		if len(selfPorts) > 0 {
+			selfRules, err := pol.compileAutoGroupSelfRules(
+				node,
+				nodes,
+				acl.Sources,
+				selfPorts,
+				protocols,
+			)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing policy, acl index: %d: %w", index, err)
+			}
+
+			aclRules = append(aclRules, selfRules...)
+		}
+
+		aclRules, err = pol.applySrcPosture(acl.SrcPosture, nodes, aclRules)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing policy, acl index: %d: %w", index, err)
+		}
+
+		rules = append(rules, aclRules...)
+		for range aclRules {
+			origins = append(origins, ruleOrigin{section: sectionACLs, index: index})
+		}
+	}
+
+	grantRules, grantOrigins, err := pol.compileGrants(node, nodes)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules = append(rules, grantRules...)
+	origins = append(origins, grantOrigins...)
+
+	// An access grant can refer to a group or tag that was removed
+	// from the policy since it was created, it is then left out instead
+	// of failing the whole policy.
+	for _, accessGrant := range pol.AccessGrants {
+		accessGrantRules, err := pol.compileAccessGrant(accessGrant.ACL, node, nodes)
+		if err != nil {
+			log.Warn().Err(err).Uint64("access_grant", accessGrant.ID).Msg("Ignoring access grant that cannot be compiled")
+
+			continue
+		}
+
+		rules = append(rules, accessGrantRules...)
+		for range accessGrantRules {
+			origins = append(origins, ruleOrigin{section: sectionAccessGrants, index: int(accessGrant.ID)})
+		}
+	}
+
+	return rules, origins, nil
+}
+
+// compileAutoGroupSelfRules generates a FilterRule per user allowing the
+// user's nodes matched by sources to reach the user's own nodes, see
+// autoGroupSelfSets.
+func (pol *ACLPolicy) compileAutoGroupSelfRules(
+	node *types.Node,
+	nodes types.Nodes,
+	sources []string,
+	ports []tailcfg.PortRange,
+	protocols []int,
+) ([]tailcfg.FilterRule, error) {
+	sets, err := pol.autoGroupSelfSets(node, nodes, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]tailcfg.FilterRule, 0, len(sets))
+	for _, set := range sets {
+		var destPorts []tailcfg.NetPortRange
+		for _, dest := range set.dsts.Prefixes() {
+			for _, port := range ports {
+				destPorts = append(destPorts, tailcfg.NetPortRange{
+					IP:    dest.String(),
+					Ports: port,
+				})
+			}
+		}
+
 		rules = append(rules, tailcfg.FilterRule{
-			SrcIPs:   srcIPs,
+			SrcIPs:   set.srcIPs,
 			DstPorts: destPorts,
 			IPProto:  protocols,
 		})
@@ -231,6 +420,86 @@ func (pol *ACLPolicy) CompileFilterRules(
 	return rules, nil
 }
 
+// selfSet is the expansion of autogroup:self for a single user.
+type selfSet struct {
+	srcIPs []string
+	dsts   *netipx.IPSet
+}
+
+// autoGroupSelfSets expands autogroup:self for every user that has nodes,
+// pairing the user's nodes matched by sources with all of the user's own
+// nodes, see selfNodes. If node is set, only the user of node is expanded.
+func (pol *ACLPolicy) autoGroupSelfSets(
+	node *types.Node,
+	nodes types.Nodes,
+	sources []string,
+) ([]selfSet, error) {
+	var users []string
+	if node != nil {
+		if pol.isTaggedNode(node) {
+			return nil, nil
+		}
+
+		users = append(users, node.User.Username())
+	} else {
+		for _, n := range nodes {
+			if !slices.Contains(users, n.User.Username()) {
+				users = append(users, n.User.Username())
+			}
+		}
+	}
+
+	var srcBuild netipx.IPSetBuilder
+	for _, src := range sources {
+		expanded, err := pol.ExpandAlias(nodes, src)
+		if err != nil {
+			return nil, err
+		}
+		srcBuild.AddSet(expanded)
+	}
+
+	srcSet, err := srcBuild.IPSet()
+	if err != nil {
+		return nil, err
+	}
+
+	var sets []selfSet
+	for _, user := range users {
+		var selfBuild netipx.IPSetBuilder
+		for _, n := range pol.selfNodes(nodes, user) {
+			n.AppendToIPSet(&selfBuild)
+		}
+
+		dsts, err := selfBuild.IPSet()
+		if err != nil {
+			return nil, err
+		}
+
+		// Only the nodes of the user itself can be a source.
+		selfBuild.Intersect(srcSet)
+		userSrcs, err := selfBuild.IPSet()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(userSrcs.Prefixes()) == 0 {
+			continue
+		}
+
+		var srcIPs []string
+		for _, prefix := range userSrcs.Prefixes() {
+			srcIPs = append(srcIPs, prefix.String())
+		}
+
+		sets = append(sets, selfSet{
+			srcIPs: srcIPs,
+			dsts:   dsts,
+		})
+	}
+
+	return sets, nil
+}
+
 // ReduceFilterRules takes a node and a set of rules and removes all rules and destinations
 // that are not relevant to that particular node.
 func ReduceFilterRules(node *types.Node, rules []tailcfg.FilterRule) []tailcfg.FilterRule {
@@ -267,11 +536,36 @@ func ReduceFilterRules(node *types.Node, rules []tailcfg.FilterRule) []tailcfg.F
 			}
 		}
 
-		if len(dests) > 0 {
+		// Application capabilities are only relevant for the node if it
+		// is one of the destinations, or routes for one.
+		var capGrants []tailcfg.CapGrant
+		for _, capGrant := range rule.CapGrant {
+			var dsts []netip.Prefix
+			for _, dst := range capGrant.Dsts {
+				if slices.ContainsFunc(node.IPs(), dst.Contains) {
+					dsts = append(dsts, dst)
+					continue
+				}
+
+				if node.Hostinfo != nil && slices.ContainsFunc(node.Hostinfo.RoutableIPs, dst.Overlaps) {
+					dsts = append(dsts, dst)
+				}
+			}
+
+			if len(dsts) > 0 {
+				capGrants = append(capGrants, tailcfg.CapGrant{
+					Dsts:   dsts,
+					CapMap: capGrant.CapMap,
+				})
+			}
+		}
+
+		if len(dests) > 0 || len(capGrants) > 0 {
 			ret = append(ret, tailcfg.FilterRule{
 				SrcIPs:   rule.SrcIPs,
 				DstPorts: dests,
 				IPProto:  rule.IPProto,
+				CapGrant: capGrants,
 			})
 		}
 	}
@@ -311,7 +605,14 @@ func (pol *ACLPolicy) CompileSSHPolicy(
 
 	for index, sshACL := range pol.SSHs {
 		var dest netipx.IPSetBuilder
+		var self bool
 		for _, src := range sshACL.Destinations {
+			if isAutoGroupSelf(src) {
+				self = true
+
+				continue
+			}
+
 			expanded, err := pol.ExpandAlias(append(peers, node), src)
 			if err != nil {
 				return nil, err
@@ -324,8 +625,15 @@ func (pol *ACLPolicy) CompileSSHPolicy(
 			return nil, err
 		}
 
+		// If the node is only a destination through autogroup:self,
+		// the principals are limited to the nodes of its own user.
+		var selfOnly bool
 		if !node.InIPSet(destSet) {
-			continue
+			if !self || pol.isTaggedNode(node) {
+				continue
+			}
+
+			selfOnly = true
 		}
 
 		action := rejectAction
@@ -343,9 +651,36 @@ func (pol *ACLPolicy) CompileSSHPolicy(
 			return nil, fmt.Errorf("parsing SSH policy, unknown action %q, index: %d: %w", sshACL.Action, index, err)
 		}
 
+		if len(sshACL.Recorders) > 0 || sshACL.EnforceRecorder {
+			err := pol.addSSHRecorders(&action, sshACL, append(peers, node))
+			if err != nil {
+				return nil, fmt.Errorf("parsing SSH policy, expanding recorders, index: %d: %w", index, err)
+			}
+		}
+
 		principals := make([]*tailcfg.SSHPrincipal, 0, len(sshACL.Sources))
 		for innerIndex, rawSrc := range sshACL.Sources {
-			if isWildcard(rawSrc) {
+			if selfOnly {
+				expandedSrcs, err := pol.ExpandAlias(
+					peers,
+					rawSrc,
+				)
+				if err != nil {
+					return nil, fmt.Errorf("parsing SSH policy, expanding alias, index: %d->%d: %w", index, innerIndex, err)
+				}
+
+				for _, selfNode := range pol.selfNodes(peers, node.User.Username()) {
+					if !selfNode.InIPSet(expandedSrcs) {
+						continue
+					}
+
+					for _, ip := range selfNode.IPs() {
+						principals = append(principals, &tailcfg.SSHPrincipal{
+							NodeIP: ip.String(),
+						})
+					}
+				}
+			} else if isWildcard(rawSrc) {
 				principals = append(principals, &tailcfg.SSHPrincipal{
 					Any: true,
 				})
@@ -355,7 +690,7 @@ func (pol *ACLPolicy) CompileSSHPolicy(
 					return nil, fmt.Errorf("parsing SSH policy, expanding user from group, index: %d->%d: %w", index, innerIndex, err)
 				}
 
-				for _, user := range users {
+				for _, user := range expandOIDCGroupUsers(users, append(peers, node)) {
 					principals = append(principals, &tailcfg.SSHPrincipal{
 						UserLogin: user,
 					})
@@ -378,6 +713,18 @@ func (pol *ACLPolicy) CompileSSHPolicy(
 
 		userMap := make(map[string]string, len(sshACL.Users))
 		for _, user := range sshACL.Users {
+			// autogroup:nonroot allows any local user, as
+			// long as it is not root, unless root is also
+			// explicitly listed.
+			if user == autoGroupNonRoot {
+				userMap["*"] = "="
+				if _, ok := userMap["root"]; !ok {
+					userMap["root"] = ""
+				}
+
+				continue
+			}
+
 			userMap[user] = "="
 		}
 		rules = append(rules, &tailcfg.SSHRule{
@@ -392,8 +739,89 @@ func (pol *ACLPolicy) CompileSSHPolicy(
 	}, nil
 }
 
-func sshCheckAction(duration string) (*tailcfg.SSHAction, error) {
-	sessionLength, err := time.ParseDuration(duration)
+// addSSHRecorders sets the session recorders of an SSH rule on its action.
+// Every recorder node is listed with all its addresses, the client uses
+// the first one it can reach. If the recorder is enforced, sessions are
+// rejected when recording cannot start and terminated when it fails, and
+// refused outright when none of the recorders exist.
+func (pol *ACLPolicy) addSSHRecorders(
+	action *tailcfg.SSHAction,
+	sshACL SSH,
+	nodes types.Nodes,
+) error {
+	if sshACL.EnforceRecorder && len(sshACL.Recorders) == 0 {
+		return ErrRecorderRequired
+	}
+
+	var recorders netipx.IPSetBuilder
+	for _, alias := range sshACL.Recorders {
+		expanded, err := pol.ExpandAlias(nodes, alias)
+		if err != nil {
+			return err
+		}
+		recorders.AddSet(expanded)
+	}
+
+	recorderSet, err := recorders.IPSet()
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		if !node.InIPSet(recorderSet) {
+			continue
+		}
+
+		for _, ip := range node.IPs() {
+			action.Recorders = append(action.Recorders, netip.AddrPortFrom(ip, sshRecorderPort))
+		}
+	}
+
+	// Clients do not record sessions without recorders, refuse them
+	// instead of letting them through unrecorded.
+	if sshACL.EnforceRecorder && len(action.Recorders) == 0 {
+		*action = tailcfg.SSHAction{
+			Reject:  true,
+			Message: sshRecordingRejectMessage + "\n",
+		}
+
+		return nil
+	}
+
+	if sshACL.EnforceRecorder {
+		action.OnRecordingFailure = sshRecordingFailureAction()
+	}
+
+	// The client records a session as decided by the final action, for a
+	// check that is the accept returned by headscale. Pass the recorders
+	// on to it.
+	if action.HoldAndDelegate != "" {
+		query := url.Values{}
+		for _, recorder := range action.Recorders {
+			query.Add(sshCheckRecorderParam, recorder.String())
+		}
+		if sshACL.EnforceRecorder {
+			query.Set(sshCheckEnforceRecorderParam, "true")
+		}
+
+		action.HoldAndDelegate += "&" + query.Encode()
+	}
+
+	return nil
+}
+
+func sshRecordingFailureAction() *tailcfg.SSHRecorderFailureAction {
+	return &tailcfg.SSHRecorderFailureAction{
+		RejectSessionWithMessage:    sshRecordingRejectMessage,
+		TerminateSessionWithMessage: sshRecordingTerminateMessage,
+	}
+}
+
+// sshCheckAction holds the SSH connection and delegates the decision to
+// headscale, which accepts it once the user of the source node has
+// re-authenticated within the check period.
+func sshCheckAction(checkPeriod string) (*tailcfg.SSHAction, error) {
+	period, err := ParseSSHCheckPeriod(checkPeriod)
 	if err != nil {
 		return nil, err
 	}
@@ -401,17 +829,87 @@ func sshCheckAction(duration string) (*tailcfg.SSHAction, error) {
 	return &tailcfg.SSHAction{
 		Message:                  "",
 		Reject:                   false,
-		Accept:                   true,
-		SessionDuration:          sessionLength,
+		Accept:                   false,
+		SessionDuration:          0,
 		AllowAgentForwarding:     true,
-		HoldAndDelegate:          "",
+		HoldAndDelegate:          SSHCheckURL(period),
 		AllowLocalPortForwarding: true,
 	}, nil
 }
 
+// SSHCheckURL returns the HoldAndDelegate URL of a check action. The URL
+// is fetched by the destination node over Noise, which ignores the host,
+// after expanding the variables.
+func SSHCheckURL(period time.Duration) string {
+	return "https://unused/machine/ssh/action/from/$SRC_NODE_ID/to/$DST_NODE_ID" +
+		"?ssh_user=$SSH_USER&local_user=$LOCAL_USER&check_period=" + period.String()
+}
+
+// ParseSSHCheckRecorders parses the session recorders that a check action
+// passes on in the query of its URL, and the action to take when recording
+// fails, which is nil if the recorder is not enforced.
+func ParseSSHCheckRecorders(query url.Values) ([]netip.AddrPort, *tailcfg.SSHRecorderFailureAction, error) {
+	var recorders []netip.AddrPort
+	for _, raw := range query[sshCheckRecorderParam] {
+		recorder, err := netip.ParseAddrPort(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		recorders = append(recorders, recorder)
+	}
+
+	if query.Get(sshCheckEnforceRecorderParam) != "true" {
+		return recorders, nil, nil
+	}
+
+	if len(recorders) == 0 {
+		return nil, nil, ErrRecorderRequired
+	}
+
+	return recorders, sshRecordingFailureAction(), nil
+}
+
+// ParseSSHCheckPeriod parses the checkPeriod of an SSH rule. An empty check
+// period defaults to 12 hours, and "always" requires a check for every
+// connection, which is returned as zero.
+func ParseSSHCheckPeriod(checkPeriod string) (time.Duration, error) {
+	switch checkPeriod {
+	case "":
+		return defaultSSHCheckPeriod, nil
+	case sshCheckAlways:
+		return 0, nil
+	}
+
+	period, err := time.ParseDuration(checkPeriod)
+	if err != nil {
+		return 0, err
+	}
+
+	if period <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCheckPeriod, checkPeriod)
+	}
+
+	return period, nil
+}
+
 func parseDestination(dest string) (string, string, error) {
 	var tokens []string
 
+	// 4via6 routes are written as via:<site-id>:<ipv4 prefix>:<ports>.
+	if isVia(dest) {
+		sep := strings.LastIndex(dest, ":")
+		alias, port := dest[:sep], dest[sep+1:]
+		if strings.Count(alias, ":") != 2 {
+			return "", "", fmt.Errorf(
+				"failed to parse destination %q: %w",
+				dest,
+				ErrInvalidPortFormat,
+			)
+		}
+
+		return alias, port, nil
+	}
+
 	// Check if there is a IPv4/6:Port combination, IPv6 has more than
 	// three ":".
 	tokens = strings.Split(dest, ":")
@@ -561,7 +1059,7 @@ func (pol *ACLPolicy) ExpandAlias(
 	}
 
 	if isAutoGroup(alias) {
-		return expandAutoGroup(alias)
+		return pol.expandAutoGroup(alias, nodes)
 	}
 
 	// if alias is a user
@@ -570,11 +1068,20 @@ func (pol *ACLPolicy) ExpandAlias(
 	}
 
 	// if alias is an host
-	// Note, this is recursive.
 	if h, ok := pol.Hosts[alias]; ok {
-		log.Trace().Str("host", h.String()).Msg("ExpandAlias got hosts entry")
+		log.Trace().Strs("host", h).Msg("ExpandAlias got hosts entry")
+
+		return pol.expandIPsFromHost(h, nodes)
+	}
+
+	// if alias is a 4via6 route
+	if isVia(alias) {
+		prefix, err := util.ParseViaAlias(alias)
+		if err != nil {
+			return nil, err
+		}
 
-		return pol.ExpandAlias(nodes, h.String())
+		return pol.expandIPsFromIPPrefix(prefix, nodes)
 	}
 
 	// if alias is an IP
@@ -728,6 +1235,13 @@ func (pol *ACLPolicy) expandUsersFromGroup(
 ) ([]string, error) {
 	var users []string
 	log.Trace().Caller().Interface("pol", pol).Msg("test")
+
+	// Members of OIDC groups are only known with the users, they are
+	// resolved when the group is matched against a user, see userMatches.
+	if isOIDCGroup(group) {
+		return []string{group}, nil
+	}
+
 	aclGroups, ok := pol.Groups[group]
 	if !ok {
 		return []string{}, fmt.Errorf(
@@ -737,7 +1251,7 @@ func (pol *ACLPolicy) expandUsersFromGroup(
 		)
 	}
 	for _, group := range aclGroups {
-		if isGroup(group) {
+		if isGroup(group) && !isOIDCGroup(group) {
 			return []string{}, fmt.Errorf(
 				"%w. A group cannot be composed of groups. https://tailscale.com/kb/1018/acls/#groups",
 				ErrInvalidGroup,
@@ -749,6 +1263,27 @@ func (pol *ACLPolicy) expandUsersFromGroup(
 	return users, nil
 }
 
+// expandOIDCGroupUsers replaces the OIDC groups in users with the users
+// that are members of them and own any of the nodes.
+func expandOIDCGroupUsers(users []string, nodes types.Nodes) []string {
+	var out []string
+	for _, user := range users {
+		if !isOIDCGroup(user) {
+			out = append(out, user)
+
+			continue
+		}
+
+		for _, node := range filterNodesByUser(nodes, user) {
+			if !slices.Contains(out, node.User.Username()) {
+				out = append(out, node.User.Username())
+			}
+		}
+	}
+
+	return out
+}
+
 func (pol *ACLPolicy) expandIPsFromGroup(
 	group string,
 	nodes types.Nodes,
@@ -880,11 +1415,123 @@ func (pol *ACLPolicy) expandIPsFromIPPrefix(
 	return build.IPSet()
 }
 
-func expandAutoGroup(alias string) (*netipx.IPSet, error) {
+// expandIPsFromHost returns the IPs of all entries of the host. Like
+// other addresses, entries also expand to all IPs of the nodes they
+// contain an IP of.
+func (pol *ACLPolicy) expandIPsFromHost(
+	host Host,
+	nodes types.Nodes,
+) (*netipx.IPSet, error) {
+	var build netipx.IPSetBuilder
+
+	for _, str := range host {
+		entry, err := parseHostEntry(str)
+		if err != nil {
+			return nil, err
+		}
+
+		var set *netipx.IPSet
+		switch {
+		case entry.addr.IsValid():
+			set, err = pol.expandIPsFromSingleIP(entry.addr, nodes)
+		case entry.prefix.IsValid():
+			set, err = pol.expandIPsFromIPPrefix(entry.prefix, nodes)
+		case entry.ipRange.IsValid():
+			set, err = pol.expandIPsFromIPRange(entry.ipRange, nodes)
+		default:
+			var nodeIPs netipx.IPSetBuilder
+			for _, node := range nodes {
+				if node.GivenName == entry.node {
+					node.AppendToIPSet(&nodeIPs)
+				}
+			}
+			set, err = nodeIPs.IPSet()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		build.AddSet(set)
+	}
+
+	return build.IPSet()
+}
+
+func (pol *ACLPolicy) expandIPsFromIPRange(
+	ipRange netipx.IPRange,
+	nodes types.Nodes,
+) (*netipx.IPSet, error) {
+	log.Trace().Str("range", ipRange.String()).Msg("expandAlias got IP range")
+	var build netipx.IPSetBuilder
+	build.AddRange(ipRange)
+
+	for _, node := range nodes {
+		if slices.ContainsFunc(node.IPs(), ipRange.Contains) {
+			node.AppendToIPSet(&build)
+		}
+	}
+
+	return build.IPSet()
+}
+
+// selfNodes returns the nodes that make up autogroup:self for the given
+// user, all nodes owned by the user that are not tagged.
+func (pol *ACLPolicy) selfNodes(nodes types.Nodes, user string) types.Nodes {
+	var out types.Nodes
+	for _, node := range filterNodesByUser(nodes, user) {
+		if !pol.isTaggedNode(node) {
+			out = append(out, node)
+		}
+	}
+
+	return out
+}
+
+// isTaggedNode reports if the node has forced tags or any requested
+// tags that are valid according to the policy.
+func (pol *ACLPolicy) isTaggedNode(node *types.Node) bool {
+	if len(node.ForcedTags) > 0 {
+		return true
+	}
+
+	validTags, _ := pol.TagsOfNode(node)
+
+	return len(validTags) > 0
+}
+
+func (pol *ACLPolicy) expandAutoGroup(
+	alias string,
+	nodes types.Nodes,
+) (*netipx.IPSet, error) {
 	switch {
 	case strings.HasPrefix(alias, "autogroup:internet"):
 		return theInternet(), nil
 
+	case isAutoGroupSelf(alias):
+		return nil, ErrAutoGroupSelf
+
+	// autogroup:member contains all nodes owned by a user,
+	// which in headscale is every node that is not tagged.
+	case alias == autoGroupMember:
+		var build netipx.IPSetBuilder
+		for _, node := range nodes {
+			if !pol.isTaggedNode(node) {
+				node.AppendToIPSet(&build)
+			}
+		}
+
+		return build.IPSet()
+
+	case alias == autoGroupTagged:
+		var build netipx.IPSetBuilder
+		for _, node := range nodes {
+			if pol.isTaggedNode(node) {
+				node.AppendToIPSet(&build)
+			}
+		}
+
+		return build.IPSet()
+
 	default:
 		return nil, fmt.Errorf("unknown autogroup %q", alias)
 	}
@@ -898,6 +1545,10 @@ func isGroup(str string) bool {
 	return strings.HasPrefix(str, "group:")
 }
 
+func isOIDCGroup(str string) bool {
+	return strings.HasPrefix(str, oidcGroupPrefix)
+}
+
 func isTag(str string) bool {
 	return strings.HasPrefix(str, "tag:")
 }
@@ -906,6 +1557,14 @@ func isAutoGroup(str string) bool {
 	return strings.HasPrefix(str, "autogroup:")
 }
 
+func isVia(str string) bool {
+	return strings.HasPrefix(str, "via:")
+}
+
+func isAutoGroupSelf(str string) bool {
+	return str == "autogroup:self"
+}
+
 // TagsOfNode will return the tags of the current node.
 // Invalid tags are tags added by a user on a node, and that user doesn't have authority to add this tag.
 // Valid tags are tags added by a user that is allowed in the ACL policy to add this tag.
@@ -932,7 +1591,7 @@ func (pol *ACLPolicy) TagsOfNode(
 			}
 			var found bool
 			for _, owner := range owners {
-				if node.User.Username() == owner {
+				if userMatches(&node.User, owner) {
 					found = true
 				}
 			}
@@ -956,7 +1615,7 @@ func (pol *ACLPolicy) TagsOfNode(
 func filterNodesByUser(nodes types.Nodes, user string) types.Nodes {
 	var out types.Nodes
 	for _, node := range nodes {
-		if node.User.Username() == user {
+		if userMatches(&node.User, user) {
 			out = append(out, node)
 		}
 	}
@@ -964,6 +1623,16 @@ func filterNodesByUser(nodes types.Nodes, user string) types.Nodes {
 	return out
 }
 
+// userMatches reports if the user is the given user of the policy, or,
+// for an OIDC group, is a member of the group.
+func userMatches(user *types.User, name string) bool {
+	if isOIDCGroup(name) {
+		return slices.Contains(user.Groups, strings.TrimPrefix(name, oidcGroupPrefix))
+	}
+
+	return user.Username() == name
+}
+
 // FilterNodesByACL returns the list of peers authorized to be accessed from a given node.
 func FilterNodesByACL(
 	node *types.Node,
@@ -3,8 +3,10 @@ package policy
 import (
 	"errors"
 	"net/netip"
+	"net/url"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/juanfont/headscale/hscontrol/types"
@@ -572,6 +574,25 @@ func (s *Suite) TestInvalidGroupInGroup(c *check.C) {
 	c.Assert(errors.Is(err, ErrInvalidGroup), check.Equals, true)
 }
 
+func (s *Suite) TestOIDCGroupInGroups(c *check.C) {
+	// this ACL is wrong because group:oidc: is reserved for the groups of
+	// the OIDC provider
+	_, err := LoadACLPolicyFromBytes([]byte(`
+{
+	"groups": {
+		"group:oidc:engineering": ["foo"],
+	},
+	"acls": [
+		{
+			"action": "accept",
+			"src": ["group:oidc:engineering"],
+			"dst": ["*:*"],
+		},
+	],
+}`))
+	c.Assert(errors.Is(err, ErrInvalidGroup), check.Equals, true)
+}
+
 func (s *Suite) TestInvalidTagOwners(c *check.C) {
 	// this ACL is wrong because no tagOwners own the requested tag for the server
 	pol := &ACLPolicy{
@@ -588,6 +609,70 @@ func (s *Suite) TestInvalidTagOwners(c *check.C) {
 	c.Assert(errors.Is(err, ErrInvalidTag), check.Equals, true)
 }
 
+func TestHostsUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Hosts
+		wantErr bool
+	}{
+		{
+			name: "single-entries",
+			data: `{"v4": "100.64.0.1", "v6": "fd7a:115c:a1e0::1", "subnet": "10.0.0.0/8"}`,
+			want: Hosts{
+				"v4":     {"100.64.0.1"},
+				"v6":     {"fd7a:115c:a1e0::1"},
+				"subnet": {"10.0.0.0/8"},
+			},
+		},
+		{
+			name: "lists",
+			data: `{"service": ["10.0.0.5", "fd00::5/128", "10.0.1.10-10.0.1.20", "database"]}`,
+			want: Hosts{
+				"service": {"10.0.0.5", "fd00::5/128", "10.0.1.10-10.0.1.20", "database"},
+			},
+		},
+		{
+			name:    "invalid-prefix",
+			data:    `{"host": "100.64.0.1/42"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid-range",
+			data:    `{"host": ["10.0.0.20-10.0.0.10"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid-name",
+			data:    `{"host": "Not A Node"}`,
+			wantErr: true,
+		},
+		{
+			name:    "empty-list",
+			data:    `{"host": []}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Hosts
+			err := got.UnmarshalJSON([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalJSON() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func Test_expandGroup(t *testing.T) {
 	type field struct {
 		pol ACLPolicy
@@ -1133,7 +1218,7 @@ func Test_expandAlias(t *testing.T) {
 			field: field{
 				pol: ACLPolicy{
 					Hosts: Hosts{
-						"testy": netip.MustParsePrefix("10.0.0.132/32"),
+						"testy": {"10.0.0.132/32"},
 					},
 				},
 			},
@@ -1149,7 +1234,7 @@ func Test_expandAlias(t *testing.T) {
 			field: field{
 				pol: ACLPolicy{
 					Hosts: Hosts{
-						"homeNetwork": netip.MustParsePrefix("192.168.1.0/24"),
+						"homeNetwork": {"192.168.1.0/24"},
 					},
 				},
 			},
@@ -1160,6 +1245,66 @@ func Test_expandAlias(t *testing.T) {
 			want:    set([]string{}, []string{"192.168.1.0/24"}),
 			wantErr: false,
 		},
+		{
+			name: "dual-stack host",
+			field: field{
+				pol: ACLPolicy{
+					Hosts: Hosts{
+						"service": {"10.0.0.5", "fd00::5"},
+					},
+				},
+			},
+			args: args{
+				alias: "service",
+				nodes: types.Nodes{},
+			},
+			want:    set([]string{"10.0.0.5", "fd00::5"}, []string{}),
+			wantErr: false,
+		},
+		{
+			name: "host with IP range",
+			field: field{
+				pol: ACLPolicy{
+					Hosts: Hosts{
+						"pool": {"10.0.0.10-10.0.0.20"},
+					},
+				},
+			},
+			args: args{
+				alias: "pool",
+				nodes: types.Nodes{},
+			},
+			want: set([]string{"10.0.0.20"}, []string{
+				"10.0.0.10/31", "10.0.0.12/30", "10.0.0.16/30",
+			}),
+			wantErr: false,
+		},
+		{
+			name: "host with node name",
+			field: field{
+				pol: ACLPolicy{
+					Hosts: Hosts{
+						"db": {"database", "10.0.0.1"},
+					},
+				},
+			},
+			args: args{
+				alias: "db",
+				nodes: types.Nodes{
+					&types.Node{
+						GivenName: "database",
+						IPv4:      iap("100.64.0.1"),
+						IPv6:      iap("fd7a:115c:a1e0::1"),
+					},
+					&types.Node{
+						GivenName: "laptop",
+						IPv4:      iap("100.64.0.2"),
+					},
+				},
+			},
+			want:    set([]string{"100.64.0.1", "fd7a:115c:a1e0::1", "10.0.0.1"}, []string{}),
+			wantErr: false,
+		},
 		{
 			name: "simple CIDR",
 			field: field{
@@ -1363,6 +1508,171 @@ func Test_expandAlias(t *testing.T) {
 			want:    set([]string{"100.64.0.4"}, []string{}),
 			wantErr: false,
 		},
+		{
+			name: "autogroup-member",
+			field: field{
+				pol: ACLPolicy{
+					TagOwners: TagOwners{"tag:hr-webserver": []string{"marc"}},
+				},
+			},
+			args: args{
+				alias: "autogroup:member",
+				nodes: types.Nodes{
+					&types.Node{
+						IPv4: iap("100.64.0.1"),
+						User: types.User{Name: "joe"},
+					},
+					&types.Node{
+						IPv4:       iap("100.64.0.2"),
+						User:       types.User{Name: "joe"},
+						ForcedTags: []string{"tag:forced"},
+					},
+					&types.Node{
+						IPv4: iap("100.64.0.3"),
+						User: types.User{Name: "marc"},
+						Hostinfo: &tailcfg.Hostinfo{
+							RequestTags: []string{"tag:hr-webserver"},
+						},
+					},
+					&types.Node{
+						IPv4: iap("100.64.0.4"),
+						User: types.User{Name: "mickael"},
+						Hostinfo: &tailcfg.Hostinfo{
+							RequestTags: []string{"tag:hr-webserver"},
+						},
+					},
+				},
+			},
+			want:    set([]string{"100.64.0.1", "100.64.0.4"}, []string{}),
+			wantErr: false,
+		},
+		{
+			name: "autogroup-tagged",
+			field: field{
+				pol: ACLPolicy{
+					TagOwners: TagOwners{"tag:hr-webserver": []string{"marc"}},
+				},
+			},
+			args: args{
+				alias: "autogroup:tagged",
+				nodes: types.Nodes{
+					&types.Node{
+						IPv4: iap("100.64.0.1"),
+						User: types.User{Name: "joe"},
+					},
+					&types.Node{
+						IPv4:       iap("100.64.0.2"),
+						User:       types.User{Name: "joe"},
+						ForcedTags: []string{"tag:forced"},
+					},
+					&types.Node{
+						IPv4: iap("100.64.0.3"),
+						User: types.User{Name: "marc"},
+						Hostinfo: &tailcfg.Hostinfo{
+							RequestTags: []string{"tag:hr-webserver"},
+						},
+					},
+					&types.Node{
+						IPv4: iap("100.64.0.4"),
+						User: types.User{Name: "mickael"},
+						Hostinfo: &tailcfg.Hostinfo{
+							RequestTags: []string{"tag:hr-webserver"},
+						},
+					},
+				},
+			},
+			want:    set([]string{"100.64.0.2", "100.64.0.3"}, []string{}),
+			wantErr: false,
+		},
+		{
+			name: "oidc-group",
+			field: field{
+				pol: ACLPolicy{},
+			},
+			args: args{
+				alias: "group:oidc:engineering",
+				nodes: types.Nodes{
+					&types.Node{
+						IPv4: iap("100.64.0.1"),
+						User: types.User{Name: "joe", Groups: []string{"engineering", "sales"}},
+					},
+					&types.Node{
+						IPv4: iap("100.64.0.2"),
+						User: types.User{Name: "marc", Groups: []string{"sales"}},
+					},
+					&types.Node{
+						IPv4: iap("100.64.0.3"),
+						User: types.User{Name: "mickael", Groups: []string{"engineering"}},
+					},
+				},
+			},
+			want:    set([]string{"100.64.0.1", "100.64.0.3"}, []string{}),
+			wantErr: false,
+		},
+		{
+			name: "group-mapped-to-oidc-groups",
+			field: field{
+				pol: ACLPolicy{
+					Groups: Groups{
+						"group:dev": []string{"group:oidc:engineering", "group:oidc:qa", "marc"},
+					},
+				},
+			},
+			args: args{
+				alias: "group:dev",
+				nodes: types.Nodes{
+					&types.Node{
+						IPv4: iap("100.64.0.1"),
+						User: types.User{Name: "joe", Groups: []string{"engineering"}},
+					},
+					&types.Node{
+						IPv4: iap("100.64.0.2"),
+						User: types.User{Name: "marc"},
+					},
+					&types.Node{
+						IPv4: iap("100.64.0.3"),
+						User: types.User{Name: "mickael", Groups: []string{"qa"}},
+					},
+					&types.Node{
+						IPv4: iap("100.64.0.4"),
+						User: types.User{Name: "john", Groups: []string{"sales"}},
+					},
+				},
+			},
+			want:    set([]string{"100.64.0.1", "100.64.0.2", "100.64.0.3"}, []string{}),
+			wantErr: false,
+		},
+		{
+			name: "tag-owned-by-oidc-group",
+			field: field{
+				pol: ACLPolicy{
+					TagOwners: TagOwners{
+						"tag:server": []string{"group:oidc:ops"},
+					},
+				},
+			},
+			args: args{
+				alias: "tag:server",
+				nodes: types.Nodes{
+					&types.Node{
+						IPv4: iap("100.64.0.1"),
+						User: types.User{Name: "joe", Groups: []string{"ops"}},
+						Hostinfo: &tailcfg.Hostinfo{
+							RequestTags: []string{"tag:server"},
+						},
+					},
+					&types.Node{
+						IPv4: iap("100.64.0.2"),
+						User: types.User{Name: "marc"},
+						Hostinfo: &tailcfg.Hostinfo{
+							RequestTags: []string{"tag:server"},
+						},
+					},
+				},
+			},
+			want:    set([]string{"100.64.0.1"}, []string{}),
+			wantErr: false,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -1658,6 +1968,43 @@ func TestACLPolicy_generateFilterRules(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "via-route",
+			field: field{
+				pol: ACLPolicy{
+					ACLs: []ACL{
+						{
+							Action:       "accept",
+							Sources:      []string{"100.64.0.1"},
+							Destinations: []string{"via:7:192.168.1.0/24:443", "via:8:192.168.1.10:22"},
+						},
+					},
+				},
+			},
+			args: args{
+				nodes: types.Nodes{
+					&types.Node{
+						IPv4: iap("100.64.0.1"),
+					},
+				},
+			},
+			want: []tailcfg.FilterRule{
+				{
+					SrcIPs: []string{"100.64.0.1/32"},
+					DstPorts: []tailcfg.NetPortRange{
+						{
+							IP:    "fd7a:115c:a1e0:b1a:0:7:c0a8:100/120",
+							Ports: tailcfg.PortRange{First: 443, Last: 443},
+						},
+						{
+							IP:    "fd7a:115c:a1e0:b1a:0:8:c0a8:10a/128",
+							Ports: tailcfg.PortRange{First: 22, Last: 22},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "host1-can-reach-host2-full",
 			field: field{
@@ -1942,7 +2289,7 @@ func TestReduceFilterRules(t *testing.T) {
 			pol: ACLPolicy{
 				Hosts: Hosts{
 					// Exit node
-					"internal": netip.MustParsePrefix("100.64.0.100/32"),
+					"internal": {"100.64.0.100/32"},
 				},
 				Groups: Groups{
 					"group:team": {"user3", "user2", "user1"},
@@ -1992,7 +2339,7 @@ func TestReduceFilterRules(t *testing.T) {
 			pol: ACLPolicy{
 				Hosts: Hosts{
 					// Exit node
-					"internal": netip.MustParsePrefix("100.64.0.100/32"),
+					"internal": {"100.64.0.100/32"},
 				},
 				Groups: Groups{
 					"group:team": {"user3", "user2", "user1"},
@@ -2059,7 +2406,7 @@ func TestReduceFilterRules(t *testing.T) {
 			pol: ACLPolicy{
 				Hosts: Hosts{
 					// Exit node
-					"internal": netip.MustParsePrefix("100.64.0.100/32"),
+					"internal": {"100.64.0.100/32"},
 				},
 				Groups: Groups{
 					"group:team": {"user3", "user2", "user1"},
@@ -2189,7 +2536,7 @@ func TestReduceFilterRules(t *testing.T) {
 			pol: ACLPolicy{
 				Hosts: Hosts{
 					// Exit node
-					"internal": netip.MustParsePrefix("100.64.0.100/32"),
+					"internal": {"100.64.0.100/32"},
 				},
 				Groups: Groups{
 					"group:team": {"user3", "user2", "user1"},
@@ -2266,7 +2613,7 @@ func TestReduceFilterRules(t *testing.T) {
 			pol: ACLPolicy{
 				Hosts: Hosts{
 					// Exit node
-					"internal": netip.MustParsePrefix("100.64.0.100/32"),
+					"internal": {"100.64.0.100/32"},
 				},
 				Groups: Groups{
 					"group:team": {"user3", "user2", "user1"},
@@ -2342,8 +2689,8 @@ func TestReduceFilterRules(t *testing.T) {
 			name: "1817-reduce-breaks-32-mask",
 			pol: ACLPolicy{
 				Hosts: Hosts{
-					"vlan1": netip.MustParsePrefix("172.16.0.0/24"),
-					"dns1":  netip.MustParsePrefix("172.16.0.21/32"),
+					"vlan1": {"172.16.0.0/24"},
+					"dns1":  {"172.16.0.21/32"},
 				},
 				Groups: Groups{
 					"group:access": {"user1"},
@@ -2395,7 +2742,62 @@ func TestReduceFilterRules(t *testing.T) {
 				},
 			},
 		},
-	}
+		{
+			name: "autogroup-self-only-own-user",
+			pol: ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"*"},
+						Destinations: []string{"autogroup:self:*"},
+					},
+				},
+			},
+			node: &types.Node{
+				IPv4: iap("100.64.0.1"),
+				IPv6: iap("fd7a:115c:a1e0::1"),
+				User: types.User{Name: "user1"},
+			},
+			peers: types.Nodes{
+				&types.Node{
+					IPv4: iap("100.64.0.2"),
+					IPv6: iap("fd7a:115c:a1e0::2"),
+					User: types.User{Name: "user1"},
+				},
+				&types.Node{
+					IPv4: iap("100.64.0.3"),
+					IPv6: iap("fd7a:115c:a1e0::3"),
+					User: types.User{Name: "user2"},
+				},
+				&types.Node{
+					IPv4:       iap("100.64.0.4"),
+					IPv6:       iap("fd7a:115c:a1e0::4"),
+					User:       types.User{Name: "user1"},
+					ForcedTags: []string{"tag:server"},
+				},
+			},
+			want: []tailcfg.FilterRule{
+				{
+					SrcIPs: []string{
+						"100.64.0.1/32",
+						"100.64.0.2/32",
+						"fd7a:115c:a1e0::1/128",
+						"fd7a:115c:a1e0::2/128",
+					},
+					DstPorts: []tailcfg.NetPortRange{
+						{
+							IP:    "100.64.0.1/32",
+							Ports: tailcfg.PortRangeAny,
+						},
+						{
+							IP:    "fd7a:115c:a1e0::1/128",
+							Ports: tailcfg.PortRangeAny,
+						},
+					},
+				},
+			},
+		},
+	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2413,6 +2815,165 @@ func TestReduceFilterRules(t *testing.T) {
 	}
 }
 
+func TestCompileFilterRulesForNode(t *testing.T) {
+	user1Node := &types.Node{
+		ID:   1,
+		IPv4: iap("100.64.0.1"),
+		User: types.User{Name: "user1"},
+	}
+	user1Node2 := &types.Node{
+		ID:   2,
+		IPv4: iap("100.64.0.2"),
+		User: types.User{Name: "user1"},
+	}
+	user2Node := &types.Node{
+		ID:   3,
+		IPv4: iap("100.64.0.3"),
+		User: types.User{Name: "user2"},
+	}
+	taggedNode := &types.Node{
+		ID:         4,
+		IPv4:       iap("100.64.0.4"),
+		User:       types.User{Name: "user2"},
+		ForcedTags: []string{"tag:server"},
+	}
+	nodes := types.Nodes{user1Node, user1Node2, user2Node, taggedNode}
+
+	selfPol := ACLPolicy{
+		ACLs: []ACL{
+			{
+				Action:       "accept",
+				Sources:      []string{"*"},
+				Destinations: []string{"autogroup:self:22", "tag:server:80"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		pol     ACLPolicy
+		node    *types.Node
+		want    []tailcfg.FilterRule
+		wantErr bool
+	}{
+		{
+			name: "self-all-users",
+			pol:  selfPol,
+			node: nil,
+			want: []tailcfg.FilterRule{
+				{
+					SrcIPs: []string{"0.0.0.0/0", "::/0"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "100.64.0.4/32", Ports: tailcfg.PortRange{First: 80, Last: 80}},
+					},
+				},
+				{
+					SrcIPs: []string{"100.64.0.1/32", "100.64.0.2/32"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "100.64.0.1/32", Ports: tailcfg.PortRange{First: 22, Last: 22}},
+						{IP: "100.64.0.2/32", Ports: tailcfg.PortRange{First: 22, Last: 22}},
+					},
+				},
+				{
+					SrcIPs: []string{"100.64.0.3/32"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "100.64.0.3/32", Ports: tailcfg.PortRange{First: 22, Last: 22}},
+					},
+				},
+			},
+		},
+		{
+			name: "self-for-node",
+			pol:  selfPol,
+			node: user2Node,
+			want: []tailcfg.FilterRule{
+				{
+					SrcIPs: []string{"0.0.0.0/0", "::/0"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "100.64.0.4/32", Ports: tailcfg.PortRange{First: 80, Last: 80}},
+					},
+				},
+				{
+					SrcIPs: []string{"100.64.0.3/32"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "100.64.0.3/32", Ports: tailcfg.PortRange{First: 22, Last: 22}},
+					},
+				},
+			},
+		},
+		{
+			name: "self-for-tagged-node",
+			pol:  selfPol,
+			node: taggedNode,
+			want: []tailcfg.FilterRule{
+				{
+					SrcIPs: []string{"0.0.0.0/0", "::/0"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "100.64.0.4/32", Ports: tailcfg.PortRange{First: 80, Last: 80}},
+					},
+				},
+			},
+		},
+		{
+			name: "self-source-limited-to-user",
+			pol: ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"user1", "user2"},
+						Destinations: []string{"autogroup:self:*"},
+					},
+				},
+			},
+			node: user1Node,
+			want: []tailcfg.FilterRule{
+				{
+					SrcIPs: []string{"100.64.0.1/32", "100.64.0.2/32"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "100.64.0.1/32", Ports: tailcfg.PortRangeAny},
+						{IP: "100.64.0.2/32", Ports: tailcfg.PortRangeAny},
+					},
+				},
+			},
+		},
+		{
+			name: "self-as-source-is-invalid",
+			pol: ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"autogroup:self"},
+						Destinations: []string{"*:*"},
+					},
+				},
+			},
+			node:    user1Node,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.pol.CompileFilterRulesForNode(tt.node, nodes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompileFilterRulesForNode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrAutoGroupSelf) {
+					t.Errorf("CompileFilterRulesForNode() error = %v, want %v", err, ErrAutoGroupSelf)
+				}
+
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CompileFilterRulesForNode() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func Test_getTags(t *testing.T) {
 	type args struct {
 		aclPolicy *ACLPolicy
@@ -3228,6 +3789,104 @@ func TestSSHRules(t *testing.T) {
 		pol   ACLPolicy
 		want  *tailcfg.SSHPolicy
 	}{
+		{
+			name: "oidc-group-can-connect",
+			node: types.Node{
+				Hostname: "server",
+				IPv4:     iap("100.64.0.10"),
+				User: types.User{
+					Name: "admin",
+				},
+			},
+			peers: types.Nodes{
+				&types.Node{
+					IPv4: iap("100.64.0.1"),
+					User: types.User{Name: "user1", Groups: []string{"ops"}},
+				},
+				&types.Node{
+					IPv4: iap("100.64.0.2"),
+					User: types.User{Name: "user1", Groups: []string{"ops"}},
+				},
+				&types.Node{
+					IPv4: iap("100.64.0.3"),
+					User: types.User{Name: "user2"},
+				},
+			},
+			pol: ACLPolicy{
+				SSHs: []SSH{
+					{
+						Action:       "accept",
+						Sources:      []string{"group:oidc:ops"},
+						Destinations: []string{"admin"},
+						Users:        []string{"root"},
+					},
+				},
+			},
+			want: &tailcfg.SSHPolicy{Rules: []*tailcfg.SSHRule{
+				{
+					Principals: []*tailcfg.SSHPrincipal{
+						{
+							UserLogin: "user1",
+						},
+					},
+					SSHUsers: map[string]string{
+						"root": "=",
+					},
+					Action: &tailcfg.SSHAction{Accept: true, AllowAgentForwarding: true, AllowLocalPortForwarding: true},
+				},
+			}},
+		},
+		{
+			name: "check-holds-and-delegates",
+			node: types.Node{
+				Hostname: "server",
+				IPv4:     iap("100.64.0.10"),
+				User:     types.User{Name: "admin"},
+			},
+			peers: types.Nodes{
+				&types.Node{
+					IPv4: iap("100.64.0.1"),
+					User: types.User{Name: "user1"},
+				},
+			},
+			pol: ACLPolicy{
+				SSHs: []SSH{
+					{
+						Action:       "check",
+						Sources:      []string{"user1"},
+						Destinations: []string{"admin"},
+						Users:        []string{"root"},
+						CheckPeriod:  "1h",
+					},
+					{
+						Action:       "check",
+						Sources:      []string{"user1"},
+						Destinations: []string{"admin"},
+						Users:        []string{"admin"},
+					},
+				},
+			},
+			want: &tailcfg.SSHPolicy{Rules: []*tailcfg.SSHRule{
+				{
+					Principals: []*tailcfg.SSHPrincipal{{NodeIP: "100.64.0.1"}},
+					SSHUsers:   map[string]string{"root": "="},
+					Action: &tailcfg.SSHAction{
+						HoldAndDelegate:          "https://unused/machine/ssh/action/from/$SRC_NODE_ID/to/$DST_NODE_ID?ssh_user=$SSH_USER&local_user=$LOCAL_USER&check_period=1h0m0s",
+						AllowAgentForwarding:     true,
+						AllowLocalPortForwarding: true,
+					},
+				},
+				{
+					Principals: []*tailcfg.SSHPrincipal{{NodeIP: "100.64.0.1"}},
+					SSHUsers:   map[string]string{"admin": "="},
+					Action: &tailcfg.SSHAction{
+						HoldAndDelegate:          "https://unused/machine/ssh/action/from/$SRC_NODE_ID/to/$DST_NODE_ID?ssh_user=$SSH_USER&local_user=$LOCAL_USER&check_period=12h0m0s",
+						AllowAgentForwarding:     true,
+						AllowLocalPortForwarding: true,
+					},
+				},
+			}},
+		},
 		{
 			name: "peers-can-connect",
 			node: types.Node{
@@ -3253,7 +3912,7 @@ func TestSSHRules(t *testing.T) {
 					"group:test": []string{"user1"},
 				},
 				Hosts: Hosts{
-					"client": netip.PrefixFrom(netip.MustParseAddr("100.64.99.42"), 32),
+					"client": {"100.64.99.42/32"},
 				},
 				ACLs: []ACL{
 					{
@@ -3297,13 +3956,15 @@ func TestSSHRules(t *testing.T) {
 						},
 					},
 					SSHUsers: map[string]string{
-						"autogroup:nonroot": "=",
+						"*":    "=",
+						"root": "",
 					},
 					Action: &tailcfg.SSHAction{Accept: true, AllowAgentForwarding: true, AllowLocalPortForwarding: true},
 				},
 				{
 					SSHUsers: map[string]string{
-						"autogroup:nonroot": "=",
+						"*":    "=",
+						"root": "",
 					},
 					Principals: []*tailcfg.SSHPrincipal{
 						{
@@ -3319,13 +3980,15 @@ func TestSSHRules(t *testing.T) {
 						},
 					},
 					SSHUsers: map[string]string{
-						"autogroup:nonroot": "=",
+						"*":    "=",
+						"root": "",
 					},
 					Action: &tailcfg.SSHAction{Accept: true, AllowAgentForwarding: true, AllowLocalPortForwarding: true},
 				},
 				{
 					SSHUsers: map[string]string{
-						"autogroup:nonroot": "=",
+						"*":    "=",
+						"root": "",
 					},
 					Principals: []*tailcfg.SSHPrincipal{
 						{
@@ -3361,7 +4024,7 @@ func TestSSHRules(t *testing.T) {
 					"group:test": []string{"user1"},
 				},
 				Hosts: Hosts{
-					"client": netip.PrefixFrom(netip.MustParseAddr("100.64.99.42"), 32),
+					"client": {"100.64.99.42/32"},
 				},
 				ACLs: []ACL{
 					{
@@ -3387,6 +4050,137 @@ func TestSSHRules(t *testing.T) {
 			},
 			want: &tailcfg.SSHPolicy{Rules: nil},
 		},
+		{
+			name: "autogroup-self-limits-principals",
+			node: types.Node{
+				Hostname: "testnodes",
+				IPv4:     iap("100.64.99.42"),
+				User: types.User{
+					Name: "user1",
+				},
+			},
+			peers: types.Nodes{
+				&types.Node{
+					Hostname: "testnodes2",
+					IPv4:     iap("100.64.0.1"),
+					User: types.User{
+						Name: "user1",
+					},
+				},
+				&types.Node{
+					Hostname: "testnodes3",
+					IPv4:     iap("100.64.0.2"),
+					User: types.User{
+						Name: "user2",
+					},
+				},
+			},
+			pol: ACLPolicy{
+				SSHs: []SSH{
+					{
+						Action:       "accept",
+						Sources:      []string{"*"},
+						Destinations: []string{"autogroup:self"},
+						Users:        []string{"root"},
+					},
+				},
+			},
+			want: &tailcfg.SSHPolicy{Rules: []*tailcfg.SSHRule{
+				{
+					Principals: []*tailcfg.SSHPrincipal{
+						{
+							NodeIP: "100.64.0.1",
+						},
+					},
+					SSHUsers: map[string]string{
+						"root": "=",
+					},
+					Action: &tailcfg.SSHAction{
+						Accept:                   true,
+						AllowAgentForwarding:     true,
+						AllowLocalPortForwarding: true,
+					},
+				},
+			}},
+		},
+		{
+			name: "autogroup-self-skips-tagged-node",
+			node: types.Node{
+				Hostname:   "testnodes",
+				IPv4:       iap("100.64.99.42"),
+				ForcedTags: []string{"tag:server"},
+				User: types.User{
+					Name: "user1",
+				},
+			},
+			peers: types.Nodes{
+				&types.Node{
+					Hostname: "testnodes2",
+					IPv4:     iap("100.64.0.1"),
+					User: types.User{
+						Name: "user1",
+					},
+				},
+			},
+			pol: ACLPolicy{
+				SSHs: []SSH{
+					{
+						Action:       "accept",
+						Sources:      []string{"*"},
+						Destinations: []string{"autogroup:self"},
+						Users:        []string{"root"},
+					},
+				},
+			},
+			want: &tailcfg.SSHPolicy{Rules: nil},
+		},
+		{
+			name: "autogroup-nonroot-and-root",
+			node: types.Node{
+				Hostname: "testnodes",
+				IPv4:     iap("100.64.99.42"),
+				User: types.User{
+					Name: "user1",
+				},
+			},
+			peers: types.Nodes{
+				&types.Node{
+					Hostname: "testnodes2",
+					IPv4:     iap("100.64.0.1"),
+					User: types.User{
+						Name: "user2",
+					},
+				},
+			},
+			pol: ACLPolicy{
+				SSHs: []SSH{
+					{
+						Action:       "accept",
+						Sources:      []string{"autogroup:member"},
+						Destinations: []string{"autogroup:member"},
+						Users:        []string{"autogroup:nonroot", "root"},
+					},
+				},
+			},
+			want: &tailcfg.SSHPolicy{Rules: []*tailcfg.SSHRule{
+				{
+					Principals: []*tailcfg.SSHPrincipal{
+						{
+							NodeIP: "100.64.0.1",
+						},
+					},
+					SSHUsers: map[string]string{
+						"*":    "=",
+						"root": "=",
+					},
+					Action: &tailcfg.SSHAction{
+						Accept:                   true,
+						AllowAgentForwarding:     true,
+						AllowLocalPortForwarding: true,
+					},
+				},
+			}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -3401,6 +4195,225 @@ func TestSSHRules(t *testing.T) {
 	}
 }
 
+func TestSSHRecorders(t *testing.T) {
+	server := types.Node{
+		Hostname: "server",
+		IPv4:     iap("100.64.0.10"),
+		User:     types.User{Name: "admin"},
+	}
+	peers := types.Nodes{
+		&types.Node{
+			Hostname: "laptop",
+			IPv4:     iap("100.64.0.1"),
+			User:     types.User{Name: "user1"},
+		},
+		&types.Node{
+			Hostname:   "recorder",
+			IPv4:       iap("100.64.0.20"),
+			IPv6:       iap("fd7a:115c:a1e0::20"),
+			User:       types.User{Name: "admin"},
+			ForcedTags: []string{"tag:recorder"},
+		},
+	}
+	rule := func(recorders []string, enforce bool) ACLPolicy {
+		return ACLPolicy{
+			Hosts: Hosts{
+				"recorder": {"100.64.0.20"},
+				"offline":  {"100.64.0.99"},
+			},
+			SSHs: []SSH{
+				{
+					Action:          "accept",
+					Sources:         []string{"user1"},
+					Destinations:    []string{"admin"},
+					Users:           []string{"root"},
+					Recorders:       recorders,
+					EnforceRecorder: enforce,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		pol     ACLPolicy
+		want    *tailcfg.SSHAction
+		wantErr error
+	}{
+		{
+			name: "tag-fails-open",
+			pol:  rule([]string{"tag:recorder"}, false),
+			want: &tailcfg.SSHAction{
+				Accept:                   true,
+				AllowAgentForwarding:     true,
+				AllowLocalPortForwarding: true,
+				Recorders: []netip.AddrPort{
+					netip.MustParseAddrPort("100.64.0.20:80"),
+					netip.MustParseAddrPort("[fd7a:115c:a1e0::20]:80"),
+				},
+			},
+		},
+		{
+			name: "host-enforced",
+			pol:  rule([]string{"recorder"}, true),
+			want: &tailcfg.SSHAction{
+				Accept:                   true,
+				AllowAgentForwarding:     true,
+				AllowLocalPortForwarding: true,
+				Recorders: []netip.AddrPort{
+					netip.MustParseAddrPort("100.64.0.20:80"),
+					netip.MustParseAddrPort("[fd7a:115c:a1e0::20]:80"),
+				},
+				OnRecordingFailure: &tailcfg.SSHRecorderFailureAction{
+					RejectSessionWithMessage:    sshRecordingRejectMessage,
+					TerminateSessionWithMessage: sshRecordingTerminateMessage,
+				},
+			},
+		},
+		{
+			name: "enforced-without-recorder-node",
+			pol:  rule([]string{"offline"}, true),
+			want: &tailcfg.SSHAction{
+				Reject:  true,
+				Message: sshRecordingRejectMessage + "\n",
+			},
+		},
+		{
+			name:    "enforced-without-recorder",
+			pol:     rule(nil, true),
+			wantErr: ErrRecorderRequired,
+		},
+		{
+			name: "check-passes-recorders-on",
+			pol: func() ACLPolicy {
+				pol := rule([]string{"tag:recorder"}, true)
+				pol.SSHs[0].Action = "check"
+				pol.SSHs[0].CheckPeriod = "1h"
+
+				return pol
+			}(),
+			want: &tailcfg.SSHAction{
+				AllowAgentForwarding:     true,
+				AllowLocalPortForwarding: true,
+				HoldAndDelegate: SSHCheckURL(time.Hour) +
+					"&enforce_recorder=true&recorder=100.64.0.20%3A80&recorder=%5Bfd7a%3A115c%3Aa1e0%3A%3A20%5D%3A80",
+				Recorders: []netip.AddrPort{
+					netip.MustParseAddrPort("100.64.0.20:80"),
+					netip.MustParseAddrPort("[fd7a:115c:a1e0::20]:80"),
+				},
+				OnRecordingFailure: &tailcfg.SSHRecorderFailureAction{
+					RejectSessionWithMessage:    sshRecordingRejectMessage,
+					TerminateSessionWithMessage: sshRecordingTerminateMessage,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.pol.CompileSSHPolicy(&server, peers)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CompileSSHPolicy() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, got.Rules[0].Action, util.Comparers...); diff != "" {
+				t.Errorf("CompileSSHPolicy() unexpected action (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseSSHCheckRecorders(t *testing.T) {
+	recorders := []netip.AddrPort{
+		netip.MustParseAddrPort("100.64.0.20:80"),
+		netip.MustParseAddrPort("[fd7a:115c:a1e0::20]:80"),
+	}
+
+	tests := []struct {
+		name          string
+		query         url.Values
+		wantRecorders []netip.AddrPort
+		wantFailure   *tailcfg.SSHRecorderFailureAction
+		wantErr       error
+	}{
+		{
+			name:  "none",
+			query: url.Values{"check_period": {"1h0m0s"}},
+		},
+		{
+			name:          "fails-open",
+			query:         url.Values{"recorder": {"100.64.0.20:80", "[fd7a:115c:a1e0::20]:80"}},
+			wantRecorders: recorders,
+		},
+		{
+			name: "enforced",
+			query: url.Values{
+				"recorder":         {"100.64.0.20:80", "[fd7a:115c:a1e0::20]:80"},
+				"enforce_recorder": {"true"},
+			},
+			wantRecorders: recorders,
+			wantFailure: &tailcfg.SSHRecorderFailureAction{
+				RejectSessionWithMessage:    sshRecordingRejectMessage,
+				TerminateSessionWithMessage: sshRecordingTerminateMessage,
+			},
+		},
+		{
+			name:    "enforced-without-recorder",
+			query:   url.Values{"enforce_recorder": {"true"}},
+			wantErr: ErrRecorderRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRecorders, gotFailure, err := ParseSSHCheckRecorders(tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseSSHCheckRecorders() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantRecorders, gotRecorders, util.Comparers...); diff != "" {
+				t.Errorf("ParseSSHCheckRecorders() unexpected recorders (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantFailure, gotFailure); diff != "" {
+				t.Errorf("ParseSSHCheckRecorders() unexpected failure action (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseSSHCheckPeriod(t *testing.T) {
+	tests := []struct {
+		period  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{period: "", want: 12 * time.Hour},
+		{period: "always", want: 0},
+		{period: "30m", want: 30 * time.Minute},
+		{period: "0s", wantErr: true},
+		{period: "-1h", wantErr: true},
+		{period: "daily", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			got, err := ParseSSHCheckPeriod(tt.period)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSSHCheckPeriod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseSSHCheckPeriod() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseDestination(t *testing.T) {
 	tests := []struct {
 		dest      string
@@ -3447,6 +4460,16 @@ func TestParseDestination(t *testing.T) {
 			wantAlias: "example-host-1",
 			wantPort:  "*",
 		},
+		{
+			dest:      "via:7:192.168.1.0/24:22",
+			wantAlias: "via:7:192.168.1.0/24",
+			wantPort:  "22",
+		},
+		{
+			dest:      "via:7:192.168.1.10:*",
+			wantAlias: "via:7:192.168.1.10",
+			wantPort:  "*",
+		},
 	}
 
 	for _, tt := range tests {
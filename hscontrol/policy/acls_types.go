@@ -2,21 +2,43 @@ package policy
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/netip"
 	"strings"
 
+	"github.com/juanfont/headscale/hscontrol/util"
 	"github.com/tailscale/hujson"
+	"go4.org/netipx"
+	"tailscale.com/tailcfg"
 )
 
+var ErrInvalidHostEntry = errors.New("invalid host entry")
+
 // ACLPolicy represents a Tailscale ACL Policy.
 type ACLPolicy struct {
 	Groups        Groups        `json:"groups"`
 	Hosts         Hosts         `json:"hosts"`
 	TagOwners     TagOwners     `json:"tagOwners"`
 	ACLs          []ACL         `json:"acls"`
+	Grants        []Grant       `json:"grants"`
 	Tests         []ACLTest     `json:"tests"`
 	AutoApprovers AutoApprovers `json:"autoApprovers"`
 	SSHs          []SSH         `json:"ssh"`
+	NodeAttrs     []NodeAttr    `json:"nodeAttrs"`
+
+	Postures          Postures `json:"postures"`
+	DefaultSrcPosture []string `json:"defaultSrcPosture,omitempty"`
+
+	// AccessGrants are temporary acls rules stored in the database,
+	// added to the policy with WithAccessGrants.
+	AccessGrants []AccessGrant `json:"-"`
+}
+
+// AccessGrant is an access grant of the database as an acls rule.
+type AccessGrant struct {
+	ID  uint64
+	ACL ACL
 }
 
 // ACL is a basic rule for the ACL Policy.
@@ -25,22 +47,69 @@ type ACL struct {
 	Protocol     string   `json:"proto"`
 	Sources      []string `json:"src"`
 	Destinations []string `json:"dst"`
+
+	// SrcPosture lists the postures a source node must meet, at least
+	// one of them, to be granted access. Defaults to the
+	// defaultSrcPosture of the policy.
+	SrcPosture []string `json:"srcPosture,omitempty"`
 }
 
+// Grant gives the sources network access and application capabilities
+// on the destinations.
+type Grant struct {
+	Sources      []string `json:"src"`
+	Destinations []string `json:"dst"`
+
+	// IP lists the network access granted, as "*", a port or port range,
+	// optionally prefixed with a protocol, e.g. "tcp:443".
+	IP []string `json:"ip,omitempty"`
+
+	// App maps application capabilities, e.g. "example.com/cap/foo", to
+	// the values passed to the destination node for each source.
+	App tailcfg.PeerCapMap `json:"app,omitempty"`
+
+	// SrcPosture is the same as for ACL.
+	SrcPosture []string `json:"srcPosture,omitempty"`
+}
+
+// NodeAttr adds and removes node attributes, the capabilities of a node
+// sent in its CapMap, e.g. "randomize-client-port" or "nextdns:abc123",
+// on the nodes matching any of the targets.
+type NodeAttr struct {
+	Targets          []string `json:"target"`
+	Attributes       []string `json:"attr,omitempty"`
+	RemoveAttributes []string `json:"removeAttr,omitempty"`
+}
+
+// Postures maps posture names, "posture:<name>", to the conditions a node
+// must meet, all of them, to match the posture. Conditions are evaluated
+// against the Hostinfo and key expiry of the node, e.g.
+// "node:tsVersion >= '1.60'", "node:os IN ['linux', 'macos']" or
+// "node:keyExpiryValid == true".
+type Postures map[string][]string
+
 // Groups references a series of alias in the ACL rules.
 type Groups map[string][]string
 
-// Hosts are alias for IP addresses or subnets.
-type Hosts map[string]netip.Prefix
+// Hosts are alias for IP addresses, subnets, IP ranges or nodes.
+type Hosts map[string]Host
+
+// Host is a list of IP addresses, subnets, IP ranges like
+// "10.0.0.10-10.0.0.20", and given names of nodes, which resolve to all IPs
+// of the node. In the policy, a host with a single entry can be written as a
+// string instead of a list.
+type Host []string
 
 // TagOwners specify what users (users?) are allow to use certain tags.
 type TagOwners map[string][]string
 
-// ACLTest is not implemented, but should be used to check if a certain rule is allowed.
+// ACLTest asserts that a source can, or cannot, reach a set of destinations.
+// Tests are evaluated before a policy is accepted, see ACLPolicy.RunTests.
 type ACLTest struct {
-	Source string   `json:"src"`
-	Accept []string `json:"accept"`
-	Deny   []string `json:"deny,omitempty"`
+	Source   string   `json:"src"`
+	Protocol string   `json:"proto,omitempty"`
+	Accept   []string `json:"accept"`
+	Deny     []string `json:"deny,omitempty"`
 }
 
 // AutoApprovers specify which users (users?), groups or tags have their advertised routes
@@ -57,40 +126,102 @@ type SSH struct {
 	Destinations []string `json:"dst"`
 	Users        []string `json:"users"`
 	CheckPeriod  string   `json:"checkPeriod,omitempty"`
+
+	// Recorders are the tags or hosts of the nodes the sessions of the
+	// rule are recorded to. If EnforceRecorder is set, sessions are
+	// refused when they cannot be recorded.
+	Recorders       []string `json:"recorder,omitempty"`
+	EnforceRecorder bool     `json:"enforceRecorder,omitempty"`
 }
 
-// UnmarshalJSON allows to parse the Hosts directly into netip objects.
+// UnmarshalJSON parses the Hosts and verifies that all entries are valid.
 func (hosts *Hosts) UnmarshalJSON(data []byte) error {
 	newHosts := Hosts{}
-	hostIPPrefixMap := make(map[string]string)
+	hostMap := make(map[string]json.RawMessage)
 	ast, err := hujson.Parse(data)
 	if err != nil {
 		return err
 	}
 	ast.Standardize()
 	data = ast.Pack()
-	err = json.Unmarshal(data, &hostIPPrefixMap)
+	err = json.Unmarshal(data, &hostMap)
 	if err != nil {
 		return err
 	}
-	for host, prefixStr := range hostIPPrefixMap {
-		if !strings.Contains(prefixStr, "/") {
-			prefixStr += "/32"
+	for name, raw := range hostMap {
+		var host Host
+
+		var entry string
+		if err := json.Unmarshal(raw, &entry); err == nil {
+			host = Host{entry}
+		} else if err := json.Unmarshal(raw, &host); err != nil {
+			return fmt.Errorf("host %q must be a string or a list of strings: %w", name, err)
 		}
-		prefix, err := netip.ParsePrefix(prefixStr)
-		if err != nil {
-			return err
+
+		if len(host) == 0 {
+			return fmt.Errorf("host %q: %w", name, ErrInvalidHostEntry)
+		}
+
+		for _, entry := range host {
+			if _, err := parseHostEntry(entry); err != nil {
+				return fmt.Errorf("host %q: %w", name, err)
+			}
 		}
-		newHosts[host] = prefix
+
+		newHosts[name] = host
 	}
 	*hosts = newHosts
 
 	return nil
 }
 
+// hostEntry is a parsed entry of a Host, only one of the fields is set.
+type hostEntry struct {
+	addr    netip.Addr
+	prefix  netip.Prefix
+	ipRange netipx.IPRange
+	node    string
+}
+
+func parseHostEntry(entry string) (hostEntry, error) {
+	if addr, err := netip.ParseAddr(entry); err == nil {
+		return hostEntry{addr: addr}, nil
+	}
+
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return hostEntry{}, fmt.Errorf("%w %q: %w", ErrInvalidHostEntry, entry, err)
+		}
+
+		return hostEntry{prefix: prefix}, nil
+	}
+
+	if from, to, ok := strings.Cut(entry, "-"); ok {
+		_, fromErr := netip.ParseAddr(from)
+		_, toErr := netip.ParseAddr(to)
+		if fromErr == nil && toErr == nil {
+			ipRange, err := netipx.ParseIPRange(entry)
+			if err != nil {
+				return hostEntry{}, fmt.Errorf("%w %q: %w", ErrInvalidHostEntry, entry, err)
+			}
+
+			return hostEntry{ipRange: ipRange}, nil
+		}
+	}
+
+	// Given names of nodes are a single DNS label.
+	if err := util.CheckForFQDNRules(entry); err != nil || strings.Contains(entry, ".") {
+		return hostEntry{}, fmt.Errorf("%w %q: not an IP address, prefix, IP range or node name", ErrInvalidHostEntry, entry)
+	}
+
+	return hostEntry{node: entry}, nil
+}
+
 // IsZero is perhaps a bit naive here.
 func (pol ACLPolicy) IsZero() bool {
-	if len(pol.Groups) == 0 && len(pol.Hosts) == 0 && len(pol.ACLs) == 0 {
+	if len(pol.Groups) == 0 && len(pol.Hosts) == 0 && len(pol.ACLs) == 0 && len(pol.Grants) == 0 &&
+		len(pol.SSHs) == 0 && len(pol.NodeAttrs) == 0 {
 		return true
 	}
 
@@ -108,7 +239,7 @@ func (autoApprovers *AutoApprovers) GetRouteApprovers(
 	approverAliases := make([]string, 0)
 
 	for autoApprovedPrefix, autoApproverAliases := range autoApprovers.Routes {
-		autoApprovedPrefix, err := netip.ParsePrefix(autoApprovedPrefix)
+		autoApprovedPrefix, err := parseAutoApprovedPrefix(autoApprovedPrefix)
 		if err != nil {
 			return nil, err
 		}
@@ -121,3 +252,13 @@ func (autoApprovers *AutoApprovers) GetRouteApprovers(
 
 	return approverAliases, nil
 }
+
+// parseAutoApprovedPrefix parses a route of autoApprovers, either a prefix
+// or a 4via6 route written as via:<site-id>:<ipv4 prefix>.
+func parseAutoApprovedPrefix(route string) (netip.Prefix, error) {
+	if isVia(route) {
+		return util.ParseViaAlias(route)
+	}
+
+	return netip.ParsePrefix(route)
+}
@@ -0,0 +1,293 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/juanfont/headscale/hscontrol/util"
+	"go4.org/netipx"
+	"tailscale.com/tailcfg"
+)
+
+var (
+	ErrPolicyTestFailed      = errors.New("policy test failed")
+	ErrPolicyTestNoAddresses = errors.New("policy test matches no nodes")
+)
+
+// ACLTestError describes an assertion in the tests section of the policy
+// that does not hold for the compiled filter rules.
+type ACLTestError struct {
+	// Index is the position of the failing test in the tests section.
+	Index int
+
+	Source      string
+	Destination string
+
+	// Accept is true if the destination was listed under accept,
+	// and false if it was listed under deny.
+	Accept bool
+}
+
+func (e *ACLTestError) Error() string {
+	if e.Accept {
+		return fmt.Sprintf(
+			"%s, test index: %d: %q cannot access %q, expected accept",
+			ErrPolicyTestFailed, e.Index, e.Source, e.Destination,
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s, test index: %d: %q can access %q, expected deny",
+		ErrPolicyTestFailed, e.Index, e.Source, e.Destination,
+	)
+}
+
+func (e *ACLTestError) Unwrap() error {
+	return ErrPolicyTestFailed
+}
+
+// RunTests compiles the filter rules for the given nodes and evaluates
+// every entry of the tests section against them. The first assertion
+// that does not hold is returned as an *ACLTestError.
+//
+// Aliases are expanded to addresses using the given nodes. A source or
+// destination without any addresses cannot be evaluated, and fails with
+// ErrPolicyTestNoAddresses, as it is most likely a typo. The tests are
+// not run if there are no nodes at all, like on a new server.
+func (pol *ACLPolicy) RunTests(nodes types.Nodes) error {
+	if pol == nil || len(pol.Tests) == 0 || len(nodes) == 0 {
+		return nil
+	}
+
+	rules, err := pol.CompileFilterRules(nodes)
+	if err != nil {
+		return err
+	}
+
+	for index, test := range pol.Tests {
+		protocols, _, err := parseProtocol(test.Protocol)
+		if err != nil {
+			return fmt.Errorf("parsing policy tests, protocol err, index: %d: %w", index, err)
+		}
+
+		// Tests without a protocol are evaluated as TCP.
+		if len(protocols) == 0 {
+			protocols = []int{protocolTCP}
+		}
+
+		srcs, err := pol.ExpandAlias(nodes, test.Source)
+		if err != nil {
+			return fmt.Errorf("parsing policy tests, expanding source, index: %d: %w", index, err)
+		}
+
+		if len(srcs.Prefixes()) == 0 {
+			return fmt.Errorf("%w, test index: %d: source %q", ErrPolicyTestNoAddresses, index, test.Source)
+		}
+
+		assertions := []struct {
+			dests  []string
+			accept bool
+		}{
+			{dests: test.Accept, accept: true},
+			{dests: test.Deny, accept: false},
+		}
+
+		for _, assertion := range assertions {
+			for _, dest := range assertion.dests {
+				ok, err := pol.testDestination(nodes, rules, srcs, dest, protocols, assertion.accept)
+				if errors.Is(err, ErrPolicyTestNoAddresses) {
+					return fmt.Errorf("%w, test index: %d: destination %q", err, index, dest)
+				}
+				if err != nil {
+					return fmt.Errorf("parsing policy tests, index: %d, destination %q: %w", index, dest, err)
+				}
+
+				if !ok {
+					return &ACLTestError{
+						Index:       index,
+						Source:      test.Source,
+						Destination: dest,
+						Accept:      assertion.accept,
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// testDestination reports if the filter rules allow (accept) or prevent
+// (!accept) all addresses in srcs from reaching the given destination.
+func (pol *ACLPolicy) testDestination(
+	nodes types.Nodes,
+	rules []tailcfg.FilterRule,
+	srcs *netipx.IPSet,
+	dest string,
+	protocols []int,
+	accept bool,
+) (bool, error) {
+	alias, portStr, err := parseDestination(dest)
+	if err != nil {
+		return false, err
+	}
+
+	port, err := strconv.ParseUint(portStr, util.Base10, util.BitSize16)
+	if err != nil {
+		return false, fmt.Errorf("tests require a single port: %w", ErrInvalidPortFormat)
+	}
+
+	dsts, err := pol.ExpandAlias(nodes, alias)
+	if err != nil {
+		return false, err
+	}
+
+	if len(dsts.Prefixes()) == 0 {
+		return false, ErrPolicyTestNoAddresses
+	}
+
+	for _, src := range testSources(srcs, rules) {
+		allowed := allowedDestinations(rules, src, uint16(port), protocols)
+
+		// Only compare addresses of the same family as the source,
+		// an IPv4 address can never reach an IPv6 address.
+		var family netipx.IPSetBuilder
+		family.AddSet(dsts)
+		if src.Is4() {
+			family.RemovePrefix(netip.MustParsePrefix("::/0"))
+		} else {
+			family.RemovePrefix(netip.MustParsePrefix("0.0.0.0/0"))
+		}
+
+		familyDsts, err := family.IPSet()
+		if err != nil {
+			return false, err
+		}
+
+		if !accept {
+			if allowed.Overlaps(familyDsts) {
+				return false, nil
+			}
+
+			continue
+		}
+
+		for _, prefix := range familyDsts.Prefixes() {
+			if !allowed.ContainsPrefix(prefix) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// testSources returns a set of addresses from srcs that is sufficient to
+// evaluate every address in srcs. The filter rules treat all addresses
+// between two boundaries of the rule sources the same, so one address
+// per such span is enough.
+func testSources(srcs *netipx.IPSet, rules []tailcfg.FilterRule) []netip.Addr {
+	var points []netip.Addr
+	for _, r := range srcs.Ranges() {
+		points = append(points, r.From())
+	}
+
+	for _, rule := range rules {
+		for _, srcIP := range rule.SrcIPs {
+			set, err := util.ParseIPSet(srcIP, nil)
+			if err != nil {
+				continue
+			}
+
+			for _, r := range set.Ranges() {
+				points = append(points, r.From())
+				if next := r.To().Next(); next.IsValid() {
+					points = append(points, next)
+				}
+			}
+		}
+	}
+
+	points = slices.DeleteFunc(points, func(addr netip.Addr) bool {
+		return !srcs.Contains(addr)
+	})
+	slices.SortFunc(points, func(a, b netip.Addr) int {
+		return a.Compare(b)
+	})
+
+	return slices.Compact(points)
+}
+
+// allowedDestinations returns the addresses the filter rules allow src
+// to reach on the given port with any of the given protocols.
+func allowedDestinations(
+	rules []tailcfg.FilterRule,
+	src netip.Addr,
+	port uint16,
+	protocols []int,
+) *netipx.IPSet {
+	var build netipx.IPSetBuilder
+
+	for _, rule := range rules {
+		if !ruleAllowsProtocol(rule, protocols) {
+			continue
+		}
+
+		srcMatch := false
+		for _, srcIP := range rule.SrcIPs {
+			set, err := util.ParseIPSet(srcIP, nil)
+			if err != nil {
+				continue
+			}
+
+			if set.Contains(src) {
+				srcMatch = true
+
+				break
+			}
+		}
+
+		if !srcMatch {
+			continue
+		}
+
+		for _, dest := range rule.DstPorts {
+			if port < dest.Ports.First || port > dest.Ports.Last {
+				continue
+			}
+
+			set, err := util.ParseIPSet(dest.IP, nil)
+			if err != nil {
+				continue
+			}
+
+			build.AddSet(set)
+		}
+	}
+
+	allowed, _ := build.IPSet()
+
+	return allowed
+}
+
+// ruleAllowsProtocol reports if the rule applies to any of the given
+// protocols. A rule without protocols applies to TCP, UDP and ICMP,
+// see tailcfg.FilterRule.
+func ruleAllowsProtocol(rule tailcfg.FilterRule, protocols []int) bool {
+	ruleProtocols := rule.IPProto
+	if len(ruleProtocols) == 0 {
+		ruleProtocols = []int{protocolTCP, protocolUDP, protocolICMP, protocolIPv6ICMP}
+	}
+
+	for _, proto := range protocols {
+		if slices.Contains(ruleProtocols, proto) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,287 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/juanfont/headscale/hscontrol/types"
+)
+
+func TestRunTests(t *testing.T) {
+	nodes := types.Nodes{
+		&types.Node{
+			ID:   1,
+			IPv4: iap("100.64.0.1"),
+			IPv6: iap("fd7a:115c:a1e0::1"),
+			User: types.User{Name: "user1"},
+		},
+		&types.Node{
+			ID:   2,
+			IPv4: iap("100.64.0.2"),
+			IPv6: iap("fd7a:115c:a1e0::2"),
+			User: types.User{Name: "user2"},
+		},
+		&types.Node{
+			ID:   3,
+			IPv4: iap("100.64.0.3"),
+			IPv6: iap("fd7a:115c:a1e0::3"),
+			User: types.User{Name: "user3"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		pol     ACLPolicy
+		want    *ACLTestError
+		wantErr bool
+		// wantErrIs is the error wrapped by the expected error.
+		wantErrIs error
+	}{
+		{
+			name: "no-tests",
+			pol: ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"*"},
+						Destinations: []string{"*:*"},
+					},
+				},
+			},
+		},
+		{
+			name: "accept-and-deny-pass",
+			pol: ACLPolicy{
+				Groups: Groups{
+					"group:admins": []string{"user1"},
+				},
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"group:admins"},
+						Destinations: []string{"user2:22,80"},
+					},
+					{
+						Action:       "accept",
+						Sources:      []string{"user2"},
+						Destinations: []string{"10.0.0.0/8:443"},
+					},
+				},
+				Tests: []ACLTest{
+					{
+						Source: "user1",
+						Accept: []string{"user2:22", "100.64.0.2:80"},
+						Deny:   []string{"user2:443", "user3:22"},
+					},
+					{
+						Source: "user2",
+						Accept: []string{"10.1.0.0/16:443"},
+						Deny:   []string{"user1:22", "10.0.0.1:80"},
+					},
+				},
+			},
+		},
+		{
+			name: "accept-fails",
+			pol: ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"user1"},
+						Destinations: []string{"user2:22"},
+					},
+				},
+				Tests: []ACLTest{
+					{
+						Source: "user1",
+						Accept: []string{"user2:22"},
+					},
+					{
+						Source: "user1",
+						Accept: []string{"user2:22", "user3:22"},
+					},
+				},
+			},
+			want: &ACLTestError{
+				Index:       1,
+				Source:      "user1",
+				Destination: "user3:22",
+				Accept:      true,
+			},
+		},
+		{
+			name: "deny-fails",
+			pol: ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"*"},
+						Destinations: []string{"*:*"},
+					},
+				},
+				Tests: []ACLTest{
+					{
+						Source: "user3",
+						Deny:   []string{"100.64.0.1:22"},
+					},
+				},
+			},
+			want: &ACLTestError{
+				Index:       0,
+				Source:      "user3",
+				Destination: "100.64.0.1:22",
+				Accept:      false,
+			},
+		},
+		{
+			name: "partial-source-fails",
+			pol: ACLPolicy{
+				Groups: Groups{
+					"group:all": []string{"user1", "user3"},
+				},
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"user1"},
+						Destinations: []string{"user2:*"},
+					},
+				},
+				Tests: []ACLTest{
+					{
+						Source: "group:all",
+						Accept: []string{"user2:22"},
+					},
+				},
+			},
+			want: &ACLTestError{
+				Index:       0,
+				Source:      "group:all",
+				Destination: "user2:22",
+				Accept:      true,
+			},
+		},
+		{
+			name: "protocol-is-respected",
+			pol: ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Protocol:     "udp",
+						Sources:      []string{"user1"},
+						Destinations: []string{"user2:53"},
+					},
+				},
+				Tests: []ACLTest{
+					{
+						Source:   "user1",
+						Protocol: "udp",
+						Accept:   []string{"user2:53"},
+					},
+					{
+						Source: "user1",
+						Deny:   []string{"user2:53"},
+					},
+				},
+			},
+		},
+		{
+			name: "source-without-nodes-fails",
+			pol: ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"user1"},
+						Destinations: []string{"user2:22"},
+					},
+				},
+				Tests: []ACLTest{
+					{
+						Source: "nobody",
+						Accept: []string{"user2:22"},
+					},
+				},
+			},
+			wantErr:   true,
+			wantErrIs: ErrPolicyTestNoAddresses,
+		},
+		{
+			name: "destination-without-nodes-fails",
+			pol: ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"user1"},
+						Destinations: []string{"user2:22"},
+					},
+				},
+				Tests: []ACLTest{
+					{
+						Source: "user1",
+						Deny:   []string{"usr2:22"},
+					},
+				},
+			},
+			wantErr:   true,
+			wantErrIs: ErrPolicyTestNoAddresses,
+		},
+		{
+			name: "port-range-is-invalid",
+			pol: ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"*"},
+						Destinations: []string{"*:*"},
+					},
+				},
+				Tests: []ACLTest{
+					{
+						Source: "user1",
+						Accept: []string{"user2:22-80"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pol.RunTests(nodes)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("RunTests() expected error, got nil")
+				}
+
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("RunTests() error = %v, want %v", err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("RunTests() unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			var testErr *ACLTestError
+			if !errors.As(err, &testErr) {
+				t.Fatalf("RunTests() expected *ACLTestError, got: %v", err)
+			}
+
+			if !errors.Is(err, ErrPolicyTestFailed) {
+				t.Errorf("RunTests() error does not wrap ErrPolicyTestFailed")
+			}
+
+			if diff := cmp.Diff(tt.want, testErr); diff != "" {
+				t.Errorf("RunTests() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"go4.org/netipx"
+	"tailscale.com/tailcfg"
+	"tailscale.com/types/ipproto"
+	"tailscale.com/types/logger"
+	"tailscale.com/wgengine/filter"
+)
+
+var (
+	ErrNoNodeForDestination = errors.New("no node serves the destination address")
+	ErrCheckProtocol        = errors.New("protocol cannot be checked")
+)
+
+const (
+	sectionACLs         = "acls"
+	sectionGrants       = "grants"
+	sectionAccessGrants = "accessGrants"
+)
+
+// AccessCheck is the result of CheckAccess.
+type AccessCheck struct {
+	Source      netip.Addr
+	Destination netip.Addr
+
+	// Node is the node the destination address belongs to, or the
+	// node routing it.
+	Node *types.Node
+
+	Allowed bool
+
+	// Matches are the rules of the policy that allow the access.
+	Matches []AccessMatch
+}
+
+// AccessMatch is a rule of the policy that allows a source to reach
+// a destination.
+type AccessMatch struct {
+	// Section is the section of the policy the rule is defined in,
+	// either "acls" or "grants", or "accessGrants" for an access grant.
+	Section string
+
+	// Index is the position of the rule in its section, or the ID of
+	// an access grant.
+	Index int
+
+	// Sources and Destinations are the entries of the rule that
+	// match the source and the destination.
+	Sources      []string
+	Destinations []string
+}
+
+// CheckAccess reports if src can reach dst on the given port and protocol.
+//
+// The answer comes from the packet filter the mapper sends to the node
+// serving dst, see ReducedRules, evaluated with the packet filter of the
+// Tailscale client, so it is the one the node would give.
+func (c *CompiledPolicy) CheckAccess(
+	src netip.Addr,
+	dst netip.Addr,
+	port uint16,
+	protocol string,
+) (*AccessCheck, error) {
+	node := nodeForDestination(c.nodes, dst)
+	if node == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoNodeForDestination, dst)
+	}
+
+	proto, err := checkProtocol(protocol, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &AccessCheck{
+		Source:      src,
+		Destination: dst,
+		Node:        node,
+	}
+
+	check.Allowed, err = checkRules(node, c.ReducedRules(node), src, dst, port, proto)
+	if err != nil {
+		return nil, err
+	}
+
+	if !check.Allowed {
+		return check, nil
+	}
+
+	// Find the entries of the policy allowing the access from the
+	// compiled rules, the rules of an entry follow each other.
+	var origins []ruleOrigin
+	for i, origin := range c.origins {
+		if len(origins) > 0 && origins[len(origins)-1] == origin {
+			continue
+		}
+
+		allowed, err := checkRules(node, ReduceFilterRules(node, c.rules[i:i+1]), src, dst, port, proto)
+		if err != nil {
+			return nil, err
+		}
+
+		if allowed {
+			origins = append(origins, origin)
+		}
+	}
+
+	for _, origin := range origins {
+		match, err := c.accessMatch(origin, node, src, dst, port, proto)
+		if err != nil {
+			return nil, err
+		}
+
+		check.Matches = append(check.Matches, *match)
+	}
+
+	return check, nil
+}
+
+// accessMatch returns the sources and destinations of the entry of the
+// policy that allow the access. The destinations are found by compiling
+// the entry with each of its destinations on its own.
+func (c *CompiledPolicy) accessMatch(
+	origin ruleOrigin,
+	node *types.Node,
+	src, dst netip.Addr,
+	port uint16,
+	proto ipproto.Proto,
+) (*AccessMatch, error) {
+	match := &AccessMatch{
+		Section: origin.section,
+		Index:   origin.index,
+	}
+
+	sources, destinations := c.pol.ruleEntries(origin)
+
+	for _, source := range sources {
+		srcs, err := c.pol.ExpandAlias(c.nodes, source)
+		if err != nil {
+			return nil, err
+		}
+
+		if srcs.Contains(src) {
+			match.Sources = append(match.Sources, source)
+		}
+	}
+
+	for _, dest := range destinations {
+		rules, err := c.pol.singleRule(origin, dest).compileFilterRules(node, c.nodes)
+		if err != nil {
+			return nil, err
+		}
+
+		allowed, err := checkRules(node, ReduceFilterRules(node, rules), src, dst, port, proto)
+		if err != nil {
+			return nil, err
+		}
+
+		if allowed {
+			match.Destinations = append(match.Destinations, dest)
+		}
+	}
+
+	return match, nil
+}
+
+// ruleEntries returns the sources and destinations of the entry of the
+// policy a rule is compiled from.
+func (pol *ACLPolicy) ruleEntries(origin ruleOrigin) ([]string, []string) {
+	switch origin.section {
+	case sectionACLs:
+		acl := pol.ACLs[origin.index]
+
+		return acl.Sources, acl.Destinations
+	case sectionGrants:
+		grant := pol.Grants[origin.index]
+
+		return grant.Sources, grant.Destinations
+	case sectionAccessGrants:
+		for _, accessGrant := range pol.AccessGrants {
+			if int(accessGrant.ID) == origin.index {
+				return accessGrant.ACL.Sources, accessGrant.ACL.Destinations
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// singleRule returns the policy with only the entry a rule is compiled
+// from, with only the given destination.
+func (pol *ACLPolicy) singleRule(origin ruleOrigin, dest string) *ACLPolicy {
+	single := *pol
+	single.ACLs = nil
+	single.Grants = nil
+	single.AccessGrants = nil
+
+	switch origin.section {
+	case sectionACLs:
+		acl := pol.ACLs[origin.index]
+		acl.Destinations = []string{dest}
+		single.ACLs = []ACL{acl}
+	case sectionGrants:
+		grant := pol.Grants[origin.index]
+		grant.Destinations = []string{dest}
+		single.Grants = []Grant{grant}
+	case sectionAccessGrants:
+		for _, accessGrant := range pol.AccessGrants {
+			if int(accessGrant.ID) == origin.index {
+				acl := accessGrant.ACL
+				acl.Destinations = []string{dest}
+				single.ACLs = []ACL{acl}
+			}
+		}
+	}
+
+	return &single
+}
+
+// checkRules reports if the packet filter of node, made of rules, allows
+// the packet.
+func checkRules(
+	node *types.Node,
+	rules []tailcfg.FilterRule,
+	src, dst netip.Addr,
+	port uint16,
+	proto ipproto.Proto,
+) (bool, error) {
+	matches, err := filter.MatchesFromFilterRules(rules)
+	if err != nil {
+		return false, err
+	}
+
+	var local netipx.IPSetBuilder
+	node.AppendToIPSet(&local)
+	for _, route := range node.Routes {
+		if route.Enabled {
+			local.AddPrefix(route.Prefix)
+		}
+	}
+
+	localNets, err := local.IPSet()
+	if err != nil {
+		return false, err
+	}
+
+	f := filter.New(matches, nil, localNets, nil, nil, logger.Discard)
+
+	return f.Check(src, dst, port, proto) == filter.Accept, nil
+}
+
+// nodeForDestination returns the node owning addr, or the node with the
+// most specific enabled route containing it.
+func nodeForDestination(nodes types.Nodes, addr netip.Addr) *types.Node {
+	if found := nodes.FilterByIP(addr); len(found) > 0 {
+		return found[0]
+	}
+
+	var (
+		router *types.Node
+		bits   = -1
+	)
+
+	for _, node := range nodes {
+		for _, route := range node.Routes {
+			if !route.Enabled || !route.Prefix.Contains(addr) {
+				continue
+			}
+
+			if route.Prefix.Bits() > bits {
+				router = node
+				bits = route.Prefix.Bits()
+			}
+		}
+	}
+
+	return router
+}
+
+// checkProtocol returns the IP protocol to check for a protocol as written
+// in the policy, defaulting to TCP.
+func checkProtocol(protocol string, dst netip.Addr) (ipproto.Proto, error) {
+	protocols, _, err := parseProtocol(protocol)
+	if err != nil {
+		return 0, err
+	}
+
+	switch len(protocols) {
+	case 0:
+		return ipproto.TCP, nil
+	case 1:
+		return ipproto.Proto(protocols[0]), nil
+	}
+
+	// icmp expands to ICMP and ICMPv6, pick the one matching the family.
+	if protocol == "icmp" {
+		if dst.Is4() {
+			return ipproto.ICMPv4, nil
+		}
+
+		return ipproto.ICMPv6, nil
+	}
+
+	return 0, fmt.Errorf("%w: %q", ErrCheckProtocol, protocol)
+}
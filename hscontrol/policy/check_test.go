@@ -0,0 +1,230 @@
+package policy
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"tailscale.com/tailcfg"
+)
+
+func TestCheckAccess(t *testing.T) {
+	nodes := types.Nodes{
+		&types.Node{
+			ID:   1,
+			IPv4: iap("100.64.0.1"),
+			IPv6: iap("fd7a:115c:a1e0::1"),
+			User: types.User{Name: "user1"},
+		},
+		&types.Node{
+			ID:   2,
+			IPv4: iap("100.64.0.2"),
+			IPv6: iap("fd7a:115c:a1e0::2"),
+			User: types.User{Name: "user2"},
+		},
+		&types.Node{
+			ID:   3,
+			IPv4: iap("100.64.0.3"),
+			User: types.User{Name: "user3"},
+			Hostinfo: &tailcfg.Hostinfo{
+				RoutableIPs: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")},
+			},
+			Routes: types.Routes{
+				{
+					Prefix:     netip.MustParsePrefix("10.0.0.0/24"),
+					Advertised: true,
+					Enabled:    true,
+				},
+			},
+		},
+	}
+
+	pol := &ACLPolicy{
+		Groups: Groups{
+			"group:admins": []string{"user1"},
+		},
+		ACLs: []ACL{
+			{
+				Action:       "accept",
+				Sources:      []string{"group:admins", "user3"},
+				Destinations: []string{"user2:22", "100.64.0.2:22,80"},
+			},
+			{
+				Action:       "accept",
+				Sources:      []string{"user1"},
+				Destinations: []string{"10.0.0.0/8:443"},
+			},
+		},
+		Grants: []Grant{
+			{
+				Sources:      []string{"*"},
+				Destinations: []string{"user2"},
+				IP:           []string{"tcp:80"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		pol      *ACLPolicy
+		src      string
+		dst      string
+		port     uint16
+		protocol string
+		want     *AccessCheck
+		wantErr  error
+	}{
+		{
+			name: "allowed-by-acl",
+			pol:  pol,
+			src:  "100.64.0.1",
+			dst:  "100.64.0.2",
+			port: 22,
+			want: &AccessCheck{
+				Node:    nodes[1],
+				Allowed: true,
+				Matches: []AccessMatch{
+					{
+						Section:      "acls",
+						Index:        0,
+						Sources:      []string{"group:admins"},
+						Destinations: []string{"user2:22", "100.64.0.2:22,80"},
+					},
+				},
+			},
+		},
+		{
+			name: "allowed-by-acl-and-grant",
+			pol:  pol,
+			src:  "fd7a:115c:a1e0::1",
+			dst:  "fd7a:115c:a1e0::2",
+			port: 80,
+			want: &AccessCheck{
+				Node:    nodes[1],
+				Allowed: true,
+				Matches: []AccessMatch{
+					{
+						Section: "acls",
+						Index:   0,
+						Sources: []string{"group:admins"},
+						// The address of a node expands to all
+						// addresses of the node.
+						Destinations: []string{"100.64.0.2:22,80"},
+					},
+					{
+						Section:      "grants",
+						Index:        0,
+						Sources:      []string{"*"},
+						Destinations: []string{"user2"},
+					},
+				},
+			},
+		},
+		{
+			name: "allowed-through-route",
+			pol:  pol,
+			src:  "100.64.0.1",
+			dst:  "10.0.0.10",
+			port: 443,
+			want: &AccessCheck{
+				Node:    nodes[2],
+				Allowed: true,
+				Matches: []AccessMatch{
+					{
+						Section:      "acls",
+						Index:        1,
+						Sources:      []string{"user1"},
+						Destinations: []string{"10.0.0.0/8:443"},
+					},
+				},
+			},
+		},
+		{
+			name: "denied-port",
+			pol:  pol,
+			src:  "100.64.0.1",
+			dst:  "100.64.0.2",
+			port: 443,
+			want: &AccessCheck{
+				Node: nodes[1],
+			},
+		},
+		{
+			name:     "denied-protocol",
+			pol:      pol,
+			src:      "100.64.0.1",
+			dst:      "100.64.0.2",
+			port:     22,
+			protocol: "sctp",
+			want: &AccessCheck{
+				Node: nodes[1],
+			},
+		},
+		{
+			name: "denied-source",
+			pol:  pol,
+			src:  "100.64.0.2",
+			dst:  "10.0.0.10",
+			port: 443,
+			want: &AccessCheck{
+				Node: nodes[2],
+			},
+		},
+		{
+			name: "no-policy-allows-all",
+			src:  "100.64.0.2",
+			dst:  "100.64.0.1",
+			port: 22,
+			want: &AccessCheck{
+				Node:    nodes[0],
+				Allowed: true,
+			},
+		},
+		{
+			name:    "unknown-destination",
+			pol:     pol,
+			src:     "100.64.0.1",
+			dst:     "10.1.0.1",
+			port:    22,
+			wantErr: ErrNoNodeForDestination,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := netip.MustParseAddr(tt.src)
+			dst := netip.MustParseAddr(tt.dst)
+
+			compiled, err := NewEngine().Compile(tt.pol, nodes, 1)
+			if err != nil {
+				t.Fatalf("Compile() unexpected error: %s", err)
+			}
+
+			got, err := compiled.CheckAccess(src, dst, tt.port, tt.protocol)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CheckAccess() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CheckAccess() unexpected error: %s", err)
+			}
+
+			tt.want.Source = src
+			tt.want.Destination = dst
+
+			if diff := cmp.Diff(tt.want, got, cmp.Comparer(func(x, y netip.Addr) bool {
+				return x == y
+			}), cmp.Comparer(func(x, y *types.Node) bool {
+				return x.ID == y.ID
+			})); diff != "" {
+				t.Errorf("CheckAccess() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
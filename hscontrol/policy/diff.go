@@ -0,0 +1,147 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/juanfont/headscale/hscontrol/util"
+	"tailscale.com/tailcfg"
+	"tailscale.com/types/ipproto"
+)
+
+// NodeImpact describes how replacing a policy changes what a node can
+// reach and which peers it sees.
+type NodeImpact struct {
+	Node *types.Node
+
+	PeersGained types.Nodes
+	PeersLost   types.Nodes
+
+	// DestinationsAdded and DestinationsRemoved are the destinations of
+	// the packet filter of the node, formatted as "<ip>:<ports>" and
+	// followed by the protocols if the rule is limited to some.
+	DestinationsAdded   []string
+	DestinationsRemoved []string
+}
+
+// DiffPolicies compiles both policies, and returns the nodes for which
+// the packet filter or the peers sent by the mapper differ. A nil policy
+// allows all traffic.
+func DiffPolicies(
+	oldPol *ACLPolicy,
+	newPol *ACLPolicy,
+	nodes types.Nodes,
+) ([]NodeImpact, error) {
+	now := time.Now()
+
+	oldCompiled, err := compilePolicy(oldPol, nodes, now)
+	if err != nil {
+		return nil, fmt.Errorf("compiling current policy: %w", err)
+	}
+
+	newCompiled, err := compilePolicy(newPol, nodes, now)
+	if err != nil {
+		return nil, fmt.Errorf("compiling new policy: %w", err)
+	}
+
+	var impacts []NodeImpact
+
+	for _, node := range nodes {
+		oldPeers, oldDests := oldCompiled.nodeView(node)
+		newPeers, newDests := newCompiled.nodeView(node)
+
+		impact := NodeImpact{
+			Node:                node,
+			PeersGained:         nodesNotIn(newPeers, oldPeers),
+			PeersLost:           nodesNotIn(oldPeers, newPeers),
+			DestinationsAdded:   stringsNotIn(newDests, oldDests),
+			DestinationsRemoved: stringsNotIn(oldDests, newDests),
+		}
+
+		if len(impact.PeersGained) == 0 && len(impact.PeersLost) == 0 &&
+			len(impact.DestinationsAdded) == 0 && len(impact.DestinationsRemoved) == 0 {
+			continue
+		}
+
+		impacts = append(impacts, impact)
+	}
+
+	return impacts, nil
+}
+
+// nodeView returns the peers of the node and the destinations of its
+// packet filter, like the mapper: all nodes are peers if the policy has
+// no rules at all.
+func (c *CompiledPolicy) nodeView(node *types.Node) (types.Nodes, []string) {
+	peers := make(types.Nodes, 0, len(c.nodes))
+	for _, peer := range c.nodes {
+		if peer.ID != node.ID {
+			peers = append(peers, peer)
+		}
+	}
+
+	if len(c.rules) > 0 {
+		peers = c.Peers(node, peers)
+	}
+
+	var dests []string
+	for _, rule := range c.ReducedRules(node) {
+		var protos string
+		if len(rule.IPProto) > 0 {
+			names := make([]string, 0, len(rule.IPProto))
+			for _, proto := range rule.IPProto {
+				name, _ := ipproto.Proto(proto).MarshalText()
+				names = append(names, string(name))
+			}
+
+			protos = " (" + strings.Join(names, ", ") + ")"
+		}
+
+		for _, dst := range rule.DstPorts {
+			dests = append(dests, dst.IP+":"+portRangeString(dst.Ports)+protos)
+		}
+	}
+
+	slices.Sort(dests)
+
+	return peers, slices.Compact(dests)
+}
+
+func portRangeString(ports tailcfg.PortRange) string {
+	switch {
+	case ports == tailcfg.PortRangeAny:
+		return "*"
+	case ports.First == ports.Last:
+		return strconv.FormatUint(uint64(ports.First), util.Base10)
+	}
+
+	return fmt.Sprintf("%d-%d", ports.First, ports.Last)
+}
+
+func nodesNotIn(nodes types.Nodes, other types.Nodes) types.Nodes {
+	var ret types.Nodes
+	for _, node := range nodes {
+		if !slices.ContainsFunc(other, func(n *types.Node) bool {
+			return n.ID == node.ID
+		}) {
+			ret = append(ret, node)
+		}
+	}
+
+	return ret
+}
+
+func stringsNotIn(strs []string, other []string) []string {
+	var ret []string
+	for _, str := range strs {
+		if !slices.Contains(other, str) {
+			ret = append(ret, str)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,176 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/juanfont/headscale/hscontrol/types"
+)
+
+func TestDiffPolicies(t *testing.T) {
+	nodes := types.Nodes{
+		&types.Node{
+			ID:        1,
+			GivenName: "laptop",
+			IPv4:      iap("100.64.0.1"),
+			User:      types.User{Name: "user1"},
+		},
+		&types.Node{
+			ID:        2,
+			GivenName: "server",
+			IPv4:      iap("100.64.0.2"),
+			User:      types.User{Name: "user2"},
+		},
+		&types.Node{
+			ID:        3,
+			GivenName: "database",
+			IPv4:      iap("100.64.0.3"),
+			User:      types.User{Name: "user3"},
+		},
+	}
+
+	oldPol := &ACLPolicy{
+		ACLs: []ACL{
+			{
+				Action:       "accept",
+				Sources:      []string{"user1"},
+				Destinations: []string{"user2:*"},
+			},
+		},
+	}
+
+	newPol := &ACLPolicy{
+		ACLs: []ACL{
+			{
+				Action:       "accept",
+				Sources:      []string{"user1"},
+				Destinations: []string{"user3:5432"},
+				Protocol:     "tcp",
+			},
+		},
+	}
+
+	// impact is a summary of NodeImpact using the names of the nodes.
+	type impact struct {
+		Node                string
+		PeersGained         []string
+		PeersLost           []string
+		DestinationsAdded   []string
+		DestinationsRemoved []string
+	}
+
+	names := func(nodes types.Nodes) []string {
+		var ret []string
+		for _, node := range nodes {
+			ret = append(ret, node.GivenName)
+		}
+
+		return ret
+	}
+
+	tests := []struct {
+		name   string
+		oldPol *ACLPolicy
+		newPol *ACLPolicy
+		want   []impact
+	}{
+		{
+			name:   "unchanged",
+			oldPol: oldPol,
+			newPol: oldPol,
+			want:   nil,
+		},
+		{
+			name:   "move-access",
+			oldPol: oldPol,
+			newPol: newPol,
+			want: []impact{
+				{
+					Node:        "laptop",
+					PeersGained: []string{"database"},
+					PeersLost:   []string{"server"},
+				},
+				{
+					Node:                "server",
+					PeersLost:           []string{"laptop"},
+					DestinationsRemoved: []string{"100.64.0.2/32:*"},
+				},
+				{
+					Node:              "database",
+					PeersGained:       []string{"laptop"},
+					DestinationsAdded: []string{"100.64.0.3/32:5432 (tcp)"},
+				},
+			},
+		},
+		{
+			name:   "from-allow-all",
+			oldPol: nil,
+			newPol: oldPol,
+			want: []impact{
+				{
+					Node:                "laptop",
+					PeersLost:           []string{"database"},
+					DestinationsRemoved: []string{"*:*"},
+				},
+				{
+					Node:      "server",
+					PeersLost: []string{"database"},
+					// The allow all destination is "*", which is
+					// narrowed down to the node itself.
+					DestinationsAdded:   []string{"100.64.0.2/32:*"},
+					DestinationsRemoved: []string{"*:*"},
+				},
+				{
+					Node:                "database",
+					PeersLost:           []string{"laptop", "server"},
+					DestinationsRemoved: []string{"*:*"},
+				},
+			},
+		},
+		{
+			// A policy without any rule does not filter the peers in
+			// the mapper, only the packet filter is emptied.
+			name:   "to-no-rules",
+			oldPol: nil,
+			newPol: &ACLPolicy{Groups: Groups{"group:admins": []string{"user1"}}},
+			want: []impact{
+				{
+					Node:                "laptop",
+					DestinationsRemoved: []string{"*:*"},
+				},
+				{
+					Node:                "server",
+					DestinationsRemoved: []string{"*:*"},
+				},
+				{
+					Node:                "database",
+					DestinationsRemoved: []string{"*:*"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impacts, err := DiffPolicies(tt.oldPol, tt.newPol, nodes)
+			if err != nil {
+				t.Fatalf("DiffPolicies() error = %v", err)
+			}
+
+			var got []impact
+			for _, i := range impacts {
+				got = append(got, impact{
+					Node:                i.Node.GivenName,
+					PeersGained:         names(i.PeersGained),
+					PeersLost:           names(i.PeersLost),
+					DestinationsAdded:   i.DestinationsAdded,
+					DestinationsRemoved: i.DestinationsRemoved,
+				})
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DiffPolicies() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
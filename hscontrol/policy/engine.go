@@ -0,0 +1,439 @@
+package policy
+
+import (
+	"cmp"
+	"net/netip"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/policy/matcher"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/rs/zerolog/log"
+	"tailscale.com/tailcfg"
+)
+
+// Engine compiles a policy once for a set of nodes, and answers the
+// questions of the mapper from the compiled result.
+//
+// The compiled policy is identified by the revision of the policy, which
+// changes every time a different policy is passed, and the generation of
+// the nodes, which changes every time NodesChanged is called. Policies are
+// compared by identity, so a policy must not be modified once passed to
+// the Engine.
+type Engine struct {
+	mu sync.Mutex
+
+	pol        *ACLPolicy
+	revision   uint64
+	generation uint64
+
+	compiled *CompiledPolicy
+}
+
+// NewEngine returns an Engine without any compiled policy.
+func NewEngine() *Engine {
+	return &Engine{generation: 1}
+}
+
+// NodesChanged drops the compiled policy. It must be called every time
+// the nodes change in a way that can change the result of compiling the
+// policy, after the change is stored.
+func (e *Engine) NodesChanged() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.generation++
+	e.compiled = nil
+}
+
+// Generation returns the generation of the nodes. It is read before
+// loading the nodes passed to Compile, so nodes loaded while they change
+// are not kept for the newer generation.
+func (e *Engine) Generation() uint64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.generation
+}
+
+// Compile returns the policy compiled for nodes, of the given generation.
+// The last compiled policy is returned if neither the policy nor the
+// nodes have changed, and no key of a node has expired since it was
+// compiled. Concurrent callers wait for a single compilation.
+func (e *Engine) Compile(pol *ACLPolicy, nodes types.Nodes, generation uint64) (*CompiledPolicy, error) {
+	now := time.Now()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if pol != e.pol || e.revision == 0 {
+		e.pol = pol
+		e.revision++
+		e.compiled = nil
+	}
+
+	if e.compiled != nil && e.compiled.Generation == generation && e.compiled.validAt(now) {
+		return e.compiled, nil
+	}
+
+	start := time.Now()
+
+	sorted := slices.Clone(nodes)
+	slices.SortFunc(sorted, func(a, b *types.Node) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	compiled, err := compilePolicy(pol, sorted, now)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled.Revision = e.revision
+	compiled.Generation = generation
+
+	// The nodes of an older generation may have been loaded before the
+	// last change, they are not kept.
+	if generation == e.generation {
+		e.compiled = compiled
+	}
+
+	log.Debug().
+		Uint64("policy.revision", compiled.Revision).
+		Uint64("nodes.generation", compiled.Generation).
+		Int("nodes", len(sorted)).
+		Dur("took", time.Since(start)).
+		Msg("Compiled policy")
+
+	return compiled, nil
+}
+
+// CompiledPolicy is a policy compiled for a set of nodes. It is safe for
+// concurrent use, results for a node are computed once and cached.
+type CompiledPolicy struct {
+	Revision   uint64
+	Generation uint64
+
+	pol     *ACLPolicy
+	rules   []tailcfg.FilterRule
+	origins []ruleOrigin
+	nodes   types.Nodes
+
+	index  map[types.NodeID]int
+	byIP   map[netip.Addr][]int
+	byUser map[string][]int
+	byTag  map[string][]int
+
+	// srcs and dsts are the nodes that are a source and a destination of
+	// every rule, the same way as Node.CanAccess matches them.
+	srcs []nodeSet
+	dsts []nodeSet
+
+	// validUntil is the first expiry of a node key after the policy has
+	// been compiled, as postures can depend on it. Zero if no key expires.
+	validUntil time.Time
+
+	mu      sync.Mutex
+	peers   map[types.NodeID]nodeSet
+	reduced map[types.NodeID][]tailcfg.FilterRule
+	own     map[types.NodeID][]tailcfg.FilterRule
+	ssh     map[types.NodeID]*tailcfg.SSHPolicy
+}
+
+func compilePolicy(pol *ACLPolicy, nodes types.Nodes, now time.Time) (*CompiledPolicy, error) {
+	rules, origins, err := pol.compileFilterRulesWithOrigins(nil, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &CompiledPolicy{
+		pol:     pol,
+		rules:   rules,
+		origins: origins,
+		nodes:   nodes,
+
+		index:  make(map[types.NodeID]int, len(nodes)),
+		byIP:   make(map[netip.Addr][]int, len(nodes)*2),
+		byUser: make(map[string][]int),
+		byTag:  make(map[string][]int),
+
+		peers:   make(map[types.NodeID]nodeSet),
+		reduced: make(map[types.NodeID][]tailcfg.FilterRule),
+		own:     make(map[types.NodeID][]tailcfg.FilterRule),
+		ssh:     make(map[types.NodeID]*tailcfg.SSHPolicy),
+	}
+
+	// routes are the addresses of the enabled routes of the nodes, which
+	// are destinations of a node in Node.CanAccess.
+	routes := make(map[netip.Addr][]int)
+
+	for i, node := range nodes {
+		c.index[node.ID] = i
+
+		for _, ip := range node.IPs() {
+			c.byIP[ip] = append(c.byIP[ip], i)
+		}
+
+		for _, route := range node.Routes {
+			if route.Enabled {
+				addr := netip.Prefix(route.Prefix).Addr()
+				routes[addr] = append(routes[addr], i)
+			}
+		}
+
+		user := node.User.Username()
+		c.byUser[user] = append(c.byUser[user], i)
+
+		tags, _ := pol.TagsOfNode(node)
+		for _, tag := range slices.Compact(slices.Sorted(slices.Values(append(tags, node.ForcedTags...)))) {
+			c.byTag[tag] = append(c.byTag[tag], i)
+		}
+
+		if node.Expiry != nil && node.Expiry.After(now) &&
+			(c.validUntil.IsZero() || node.Expiry.Before(c.validUntil)) {
+			c.validUntil = *node.Expiry
+		}
+	}
+
+	c.srcs = make([]nodeSet, len(rules))
+	c.dsts = make([]nodeSet, len(rules))
+	for i, rule := range rules {
+		match := matcher.MatchFromFilterRule(rule)
+
+		c.srcs[i] = c.nodesIn(match.Srcs.Prefixes(), c.byIP, nil)
+		c.dsts[i] = c.nodesIn(match.Dests.Prefixes(), c.byIP, routes)
+	}
+
+	return c, nil
+}
+
+func (c *CompiledPolicy) validAt(now time.Time) bool {
+	return c.validUntil.IsZero() || now.Before(c.validUntil)
+}
+
+// nodesIn returns the nodes with an address in one of the prefixes,
+// looking single addresses up in the indexes.
+func (c *CompiledPolicy) nodesIn(
+	prefixes []netip.Prefix,
+	ips map[netip.Addr][]int,
+	routes map[netip.Addr][]int,
+) nodeSet {
+	set := newNodeSet(len(c.nodes))
+
+	for _, prefix := range prefixes {
+		if prefix.IsSingleIP() {
+			for _, i := range ips[prefix.Addr()] {
+				set.add(i)
+			}
+
+			for _, i := range routes[prefix.Addr()] {
+				set.add(i)
+			}
+
+			continue
+		}
+
+		for addr, nodes := range ips {
+			if prefix.Contains(addr) {
+				for _, i := range nodes {
+					set.add(i)
+				}
+			}
+		}
+
+		for addr, nodes := range routes {
+			if prefix.Contains(addr) {
+				for _, i := range nodes {
+					set.add(i)
+				}
+			}
+		}
+	}
+
+	return set
+}
+
+// Rules returns the FilterRules of the policy for all nodes.
+func (c *CompiledPolicy) Rules() []tailcfg.FilterRule {
+	return c.rules
+}
+
+// ReducedRules returns the FilterRules relevant to the node, see
+// ReduceFilterRules.
+func (c *CompiledPolicy) ReducedRules(node *types.Node) []tailcfg.FilterRule {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if rules, ok := c.reduced[node.ID]; ok {
+		return rules
+	}
+
+	rules := ReduceFilterRules(node, c.rules)
+	if _, ok := c.index[node.ID]; ok {
+		c.reduced[node.ID] = rules
+	}
+
+	return rules
+}
+
+// NodeRules returns the FilterRules of the policy compiled for the node,
+// see ACLPolicy.CompileFilterRulesForNode. Unlike Rules, they do not
+// contain the rules of other users, like their autogroup:self.
+func (c *CompiledPolicy) NodeRules(node *types.Node) ([]tailcfg.FilterRule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if rules, ok := c.own[node.ID]; ok {
+		return rules, nil
+	}
+
+	rules, err := c.pol.CompileFilterRulesForNode(node, c.nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := c.index[node.ID]; ok {
+		c.own[node.ID] = rules
+	}
+
+	return rules, nil
+}
+
+// Peers returns the nodes of candidates that the node can access, or that
+// can access the node, like FilterNodesByACL.
+func (c *CompiledPolicy) Peers(node *types.Node, candidates types.Nodes) types.Nodes {
+	visible, ok := c.visibleFrom(node)
+	if !ok {
+		return FilterNodesByACL(node, candidates, c.rules)
+	}
+
+	var result types.Nodes
+	for _, peer := range candidates {
+		if peer.ID == node.ID {
+			continue
+		}
+
+		i, ok := c.index[peer.ID]
+		if !ok {
+			if node.CanAccess(c.rules, peer) || peer.CanAccess(c.rules, node) {
+				result = append(result, peer)
+			}
+
+			continue
+		}
+
+		if visible.has(i) {
+			result = append(result, peer)
+		}
+	}
+
+	return result
+}
+
+func (c *CompiledPolicy) visibleFrom(node *types.Node) (nodeSet, bool) {
+	i, ok := c.index[node.ID]
+	if !ok {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if visible, ok := c.peers[node.ID]; ok {
+		return visible, true
+	}
+
+	visible := newNodeSet(len(c.nodes))
+	for r := range c.rules {
+		if c.srcs[r].has(i) {
+			visible.union(c.dsts[r])
+		}
+
+		if c.dsts[r].has(i) {
+			visible.union(c.srcs[r])
+		}
+	}
+
+	c.peers[node.ID] = visible
+
+	return visible, true
+}
+
+// SSHPolicy returns the SSH policy of the node, with all other nodes the
+// policy has been compiled for as peers.
+func (c *CompiledPolicy) SSHPolicy(node *types.Node) (*tailcfg.SSHPolicy, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if sshPolicy, ok := c.ssh[node.ID]; ok {
+		return sshPolicy, nil
+	}
+
+	peers := make(types.Nodes, 0, len(c.nodes))
+	for _, peer := range c.nodes {
+		if peer.ID != node.ID {
+			peers = append(peers, peer)
+		}
+	}
+
+	sshPolicy, err := c.pol.CompileSSHPolicy(node, peers)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := c.index[node.ID]; ok {
+		c.ssh[node.ID] = sshPolicy
+	}
+
+	return sshPolicy, nil
+}
+
+// NodeByIP returns the node with the given IP address.
+func (c *CompiledPolicy) NodeByIP(ip netip.Addr) *types.Node {
+	for _, i := range c.byIP[ip] {
+		return c.nodes[i]
+	}
+
+	return nil
+}
+
+// NodesByUser returns the nodes of the user.
+func (c *CompiledPolicy) NodesByUser(user string) types.Nodes {
+	return c.nodesAt(c.byUser[user])
+}
+
+// NodesByTag returns the nodes with the tag, either valid requested tags
+// or forced tags.
+func (c *CompiledPolicy) NodesByTag(tag string) types.Nodes {
+	return c.nodesAt(c.byTag[tag])
+}
+
+func (c *CompiledPolicy) nodesAt(indexes []int) types.Nodes {
+	nodes := make(types.Nodes, 0, len(indexes))
+	for _, i := range indexes {
+		nodes = append(nodes, c.nodes[i])
+	}
+
+	return nodes
+}
+
+// nodeSet is a set of nodes, by their position in CompiledPolicy.nodes.
+type nodeSet []uint64
+
+func newNodeSet(size int) nodeSet {
+	return make(nodeSet, (size+63)/64)
+}
+
+func (s nodeSet) add(i int) {
+	s[i/64] |= 1 << (i % 64)
+}
+
+func (s nodeSet) has(i int) bool {
+	return s[i/64]&(1<<(i%64)) != 0
+}
+
+func (s nodeSet) union(other nodeSet) {
+	for i := range s {
+		s[i] |= other[i]
+	}
+}
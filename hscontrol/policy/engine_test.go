@@ -0,0 +1,225 @@
+package policy
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tailscale.com/tailcfg"
+)
+
+func engineTestNodes() types.Nodes {
+	return types.Nodes{
+		&types.Node{
+			ID:        1,
+			GivenName: "laptop",
+			IPv4:      iap("100.64.0.1"),
+			IPv6:      iap("fd7a:115c:a1e0::1"),
+			User:      types.User{Name: "user1"},
+		},
+		&types.Node{
+			ID:        2,
+			GivenName: "phone",
+			IPv4:      iap("100.64.0.2"),
+			User:      types.User{Name: "user1"},
+		},
+		&types.Node{
+			ID:         3,
+			GivenName:  "server",
+			IPv4:       iap("100.64.0.3"),
+			User:       types.User{Name: "user2"},
+			ForcedTags: []string{"tag:server"},
+		},
+		&types.Node{
+			ID:        4,
+			GivenName: "router",
+			IPv4:      iap("100.64.0.4"),
+			User:      types.User{Name: "user3"},
+			Hostinfo: &tailcfg.Hostinfo{
+				RoutableIPs: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+			},
+			Routes: types.Routes{
+				{
+					Prefix:     netip.MustParsePrefix("10.0.0.0/8"),
+					Advertised: true,
+					Enabled:    true,
+				},
+			},
+		},
+		&types.Node{
+			ID:        5,
+			GivenName: "printer",
+			IPv4:      iap("100.64.0.5"),
+			User:      types.User{Name: "user4"},
+		},
+	}
+}
+
+func TestEngineMatchesPolicy(t *testing.T) {
+	nodes := engineTestNodes()
+
+	tests := []struct {
+		name string
+		pol  *ACLPolicy
+	}{
+		{
+			name: "nil-policy",
+			pol:  nil,
+		},
+		{
+			name: "users-tags-and-routes",
+			pol: &ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"user1"},
+						Destinations: []string{"tag:server:22", "10.1.0.0/16:*"},
+					},
+					{
+						Action:       "accept",
+						Sources:      []string{"tag:server"},
+						Destinations: []string{"100.64.0.0/30:443"},
+						Protocol:     "tcp",
+					},
+				},
+			},
+		},
+		{
+			name: "autogroup-self",
+			pol: &ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"*"},
+						Destinations: []string{"autogroup:self:*"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine()
+			compiled, err := engine.Compile(tt.pol, nodes, engine.Generation())
+			require.NoError(t, err)
+
+			for _, node := range nodes {
+				rules, err := tt.pol.CompileFilterRulesForNode(node, nodes)
+				require.NoError(t, err)
+
+				wantPeers := nodeNames(FilterNodesByACL(node, nodes, rules))
+				gotPeers := nodeNames(compiled.Peers(node, nodes))
+				if diff := cmp.Diff(wantPeers, gotPeers); diff != "" {
+					t.Errorf("Peers(%s) unexpected result (-want +got):\n%s", node.GivenName, diff)
+				}
+
+				wantRules := ReduceFilterRules(node, rules)
+				if diff := cmp.Diff(wantRules, compiled.ReducedRules(node)); diff != "" {
+					t.Errorf("ReducedRules(%s) unexpected result (-want +got):\n%s", node.GivenName, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestEngineCache(t *testing.T) {
+	nodes := engineTestNodes()
+	pol := &ACLPolicy{
+		ACLs: []ACL{
+			{
+				Action:       "accept",
+				Sources:      []string{"user1"},
+				Destinations: []string{"user2:*"},
+			},
+		},
+	}
+
+	engine := NewEngine()
+
+	generation := engine.Generation()
+	first, err := engine.Compile(pol, nodes, generation)
+	require.NoError(t, err)
+
+	// The order of the nodes does not matter.
+	reversed := types.Nodes{nodes[4], nodes[3], nodes[2], nodes[1], nodes[0]}
+	second, err := engine.Compile(pol, reversed, generation)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	changed := engineTestNodes()
+	changed[2].User = types.User{Name: "user1"}
+	engine.NodesChanged()
+	third, err := engine.Compile(pol, changed, engine.Generation())
+	require.NoError(t, err)
+	assert.NotSame(t, second, third)
+	assert.Equal(t, first.Revision, third.Revision)
+	assert.Greater(t, third.Generation, first.Generation)
+	assert.Len(t, third.NodesByUser("user1"), 3)
+
+	// Nodes loaded before the change are compiled, but not kept.
+	stale, err := engine.Compile(pol, nodes, generation)
+	require.NoError(t, err)
+	assert.Len(t, stale.NodesByUser("user1"), 2)
+	again, err := engine.Compile(pol, changed, engine.Generation())
+	require.NoError(t, err)
+	assert.Same(t, third, again)
+
+	newPol := &ACLPolicy{}
+	fourth, err := engine.Compile(newPol, changed, engine.Generation())
+	require.NoError(t, err)
+	assert.Greater(t, fourth.Revision, third.Revision)
+	assert.Equal(t, third.Generation, fourth.Generation)
+
+	// A key that expires makes the cache invalid, as postures can depend on it.
+	expiry := time.Now().Add(time.Millisecond)
+	changed[0].Expiry = &expiry
+	engine.NodesChanged()
+	fifth, err := engine.Compile(newPol, changed, engine.Generation())
+	require.NoError(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+
+	sixth, err := engine.Compile(newPol, changed, engine.Generation())
+	require.NoError(t, err)
+	assert.NotSame(t, fifth, sixth)
+	assert.Equal(t, fifth.Generation, sixth.Generation)
+}
+
+func TestCompiledPolicyLookups(t *testing.T) {
+	nodes := engineTestNodes()
+	pol := &ACLPolicy{
+		TagOwners: TagOwners{
+			"tag:printer": []string{"user4"},
+		},
+	}
+	nodes[4].Hostinfo = &tailcfg.Hostinfo{
+		RequestTags: []string{"tag:printer"},
+	}
+
+	engine := NewEngine()
+	compiled, err := engine.Compile(pol, nodes, engine.Generation())
+	require.NoError(t, err)
+
+	assert.Equal(t, "phone", compiled.NodeByIP(netip.MustParseAddr("100.64.0.2")).GivenName)
+	assert.Equal(t, "laptop", compiled.NodeByIP(netip.MustParseAddr("fd7a:115c:a1e0::1")).GivenName)
+	assert.Nil(t, compiled.NodeByIP(netip.MustParseAddr("100.64.0.9")))
+
+	assert.Equal(t, []string{"laptop", "phone"}, nodeNames(compiled.NodesByUser("user1")))
+	assert.Equal(t, []string{"server"}, nodeNames(compiled.NodesByTag("tag:server")))
+	assert.Equal(t, []string{"printer"}, nodeNames(compiled.NodesByTag("tag:printer")))
+	assert.Empty(t, compiled.NodesByTag("tag:unknown"))
+}
+
+func nodeNames(nodes types.Nodes) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.GivenName)
+	}
+
+	return names
+}
@@ -0,0 +1,181 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"go4.org/netipx"
+	"tailscale.com/tailcfg"
+)
+
+// grantIP is the network access of a grant for a single protocol.
+type grantIP struct {
+	protocols []int
+	ports     []tailcfg.PortRange
+}
+
+// compileGrants generates the FilterRules for the grants section of the
+// policy. Network access becomes a rule per protocol, and application
+// capabilities a rule with a CapGrant, which the destination node uses to
+// answer WhoIs requests for the sources. The grant of every rule is
+// returned as its origin.
+func (pol *ACLPolicy) compileGrants(
+	node *types.Node,
+	nodes types.Nodes,
+) ([]tailcfg.FilterRule, []ruleOrigin, error) {
+	var (
+		rules   []tailcfg.FilterRule
+		origins []ruleOrigin
+	)
+
+	for index, grant := range pol.Grants {
+		if len(grant.IP) == 0 && len(grant.App) == 0 {
+			return nil, nil, fmt.Errorf("parsing policy, grant index: %d: %w", index, ErrInvalidGrant)
+		}
+
+		ips, err := parseGrantIPs(grant.IP)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing policy, grant index: %d: %w", index, err)
+		}
+
+		var srcIPs []string
+		for srcIndex, src := range grant.Sources {
+			srcs, err := pol.expandSource(src, nodes)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing policy, grant index: %d->%d: %w", index, srcIndex, err)
+			}
+			srcIPs = append(srcIPs, srcs...)
+		}
+
+		var dstBuild netipx.IPSetBuilder
+		var self bool
+		for dstIndex, dst := range grant.Destinations {
+			if isAutoGroupSelf(dst) {
+				self = true
+
+				continue
+			}
+
+			expanded, err := pol.ExpandAlias(nodes, dst)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing policy, grant index: %d->%d: %w", index, dstIndex, err)
+			}
+			dstBuild.AddSet(expanded)
+		}
+
+		dsts, err := dstBuild.IPSet()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var grantRules []tailcfg.FilterRule
+		if len(dsts.Prefixes()) > 0 {
+			grantRules = append(grantRules, grantFilterRules(srcIPs, dsts, ips, grant.App)...)
+		}
+
+		if self {
+			sets, err := pol.autoGroupSelfSets(node, nodes, grant.Sources)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing policy, grant index: %d: %w", index, err)
+			}
+
+			for _, set := range sets {
+				grantRules = append(grantRules, grantFilterRules(set.srcIPs, set.dsts, ips, grant.App)...)
+			}
+		}
+
+		grantRules, err = pol.applySrcPosture(grant.SrcPosture, nodes, grantRules)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing policy, grant index: %d: %w", index, err)
+		}
+
+		rules = append(rules, grantRules...)
+		for range grantRules {
+			origins = append(origins, ruleOrigin{section: sectionGrants, index: index})
+		}
+	}
+
+	return rules, origins, nil
+}
+
+// grantFilterRules converts a grant with expanded sources and destinations
+// to FilterRules.
+func grantFilterRules(
+	srcIPs []string,
+	dsts *netipx.IPSet,
+	ips []grantIP,
+	app tailcfg.PeerCapMap,
+) []tailcfg.FilterRule {
+	var rules []tailcfg.FilterRule
+
+	for _, ip := range ips {
+		var destPorts []tailcfg.NetPortRange
+		for _, dest := range dsts.Prefixes() {
+			for _, port := range ip.ports {
+				destPorts = append(destPorts, tailcfg.NetPortRange{
+					IP:    dest.String(),
+					Ports: port,
+				})
+			}
+		}
+
+		rules = append(rules, tailcfg.FilterRule{
+			SrcIPs:   srcIPs,
+			DstPorts: destPorts,
+			IPProto:  ip.protocols,
+		})
+	}
+
+	if len(app) > 0 {
+		rules = append(rules, tailcfg.FilterRule{
+			SrcIPs: srcIPs,
+			CapGrant: []tailcfg.CapGrant{
+				{
+					Dsts:   dsts.Prefixes(),
+					CapMap: app,
+				},
+			},
+		})
+	}
+
+	return rules
+}
+
+// parseGrantIPs parses the ip field of a grant, entries like "*", "443",
+// "tcp:80-88" or "icmp:*", and groups the ports by protocol.
+func parseGrantIPs(ips []string) ([]grantIP, error) {
+	var ret []grantIP
+	byProtocol := make(map[string]int)
+
+	for _, ip := range ips {
+		protocol, portsStr, found := strings.Cut(ip, ":")
+		if !found {
+			protocol, portsStr = "", ip
+		}
+
+		protocols, needsWildcard, err := parseProtocol(protocol)
+		if err != nil {
+			return nil, fmt.Errorf("parsing ip %q: %w", ip, err)
+		}
+
+		ports, err := expandPorts(portsStr, needsWildcard)
+		if err != nil {
+			return nil, fmt.Errorf("parsing ip %q: %w", ip, err)
+		}
+
+		if i, ok := byProtocol[protocol]; ok {
+			ret[i].ports = append(ret[i].ports, *ports...)
+
+			continue
+		}
+
+		byProtocol[protocol] = len(ret)
+		ret = append(ret, grantIP{
+			protocols: protocols,
+			ports:     *ports,
+		})
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,319 @@
+package policy
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/juanfont/headscale/hscontrol/util"
+	"tailscale.com/tailcfg"
+)
+
+func TestCompileGrants(t *testing.T) {
+	nodes := types.Nodes{
+		&types.Node{
+			ID:   1,
+			IPv4: iap("100.64.0.1"),
+			User: types.User{Name: "user1"},
+		},
+		&types.Node{
+			ID:   2,
+			IPv4: iap("100.64.0.2"),
+			User: types.User{Name: "user2"},
+		},
+		&types.Node{
+			ID:   3,
+			IPv4: iap("100.64.0.3"),
+			User: types.User{Name: "user2"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		acl     string
+		node    *types.Node
+		want    []tailcfg.FilterRule
+		wantErr bool
+	}{
+		{
+			name: "ip-and-app",
+			acl: `
+{
+	"grants": [
+		{
+			"src": ["user1"],
+			"dst": ["100.64.0.2"],
+			"ip": ["tcp:443", "tcp:80", "udp:53"],
+			"app": {
+				"example.com/cap/foo": [{"role": "admin"}],
+			},
+		},
+	],
+}`,
+			want: []tailcfg.FilterRule{
+				{
+					SrcIPs: []string{"100.64.0.1/32"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "100.64.0.2/32", Ports: tailcfg.PortRange{First: 443, Last: 443}},
+						{IP: "100.64.0.2/32", Ports: tailcfg.PortRange{First: 80, Last: 80}},
+					},
+					IPProto: []int{protocolTCP},
+				},
+				{
+					SrcIPs: []string{"100.64.0.1/32"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "100.64.0.2/32", Ports: tailcfg.PortRange{First: 53, Last: 53}},
+					},
+					IPProto: []int{protocolUDP},
+				},
+				{
+					SrcIPs: []string{"100.64.0.1/32"},
+					CapGrant: []tailcfg.CapGrant{
+						{
+							Dsts: []netip.Prefix{netip.MustParsePrefix("100.64.0.2/32")},
+							CapMap: tailcfg.PeerCapMap{
+								"example.com/cap/foo": []tailcfg.RawMessage{`{"role": "admin"}`},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "app-only",
+			acl: `
+{
+	"grants": [
+		{
+			"src": ["*"],
+			"dst": ["user2"],
+			"app": {
+				"example.com/cap/bar": [],
+			},
+		},
+	],
+}`,
+			want: []tailcfg.FilterRule{
+				{
+					SrcIPs: []string{"0.0.0.0/0", "::/0"},
+					CapGrant: []tailcfg.CapGrant{
+						{
+							Dsts: []netip.Prefix{netip.MustParsePrefix("100.64.0.2/31")},
+							CapMap: tailcfg.PeerCapMap{
+								"example.com/cap/bar": []tailcfg.RawMessage{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "app-to-self-for-node",
+			acl: `
+{
+	"grants": [
+		{
+			"src": ["autogroup:member"],
+			"dst": ["autogroup:self"],
+			"ip": ["*"],
+			"app": {
+				"example.com/cap/self": [{}],
+			},
+		},
+	],
+}`,
+			node: nodes[1],
+			want: []tailcfg.FilterRule{
+				{
+					SrcIPs: []string{"100.64.0.2/31"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "100.64.0.2/31", Ports: tailcfg.PortRangeAny},
+					},
+				},
+				{
+					SrcIPs: []string{"100.64.0.2/31"},
+					CapGrant: []tailcfg.CapGrant{
+						{
+							Dsts: []netip.Prefix{netip.MustParsePrefix("100.64.0.2/31")},
+							CapMap: tailcfg.PeerCapMap{
+								"example.com/cap/self": []tailcfg.RawMessage{`{}`},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no-ip-or-app",
+			acl: `
+{
+	"grants": [
+		{
+			"src": ["*"],
+			"dst": ["*"],
+		},
+	],
+}`,
+			wantErr: true,
+		},
+		{
+			name: "invalid-ip",
+			acl: `
+{
+	"grants": [
+		{
+			"src": ["*"],
+			"dst": ["*"],
+			"ip": ["icmp:22"],
+		},
+	],
+}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol, err := LoadACLPolicyFromBytes([]byte(tt.acl))
+			if err != nil {
+				t.Fatalf("parsing policy: %s", err)
+			}
+
+			got, err := pol.CompileFilterRulesForNode(tt.node, nodes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompileFilterRulesForNode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got, util.Comparers...); diff != "" {
+				t.Errorf("CompileFilterRulesForNode() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReduceFilterRulesCapGrant(t *testing.T) {
+	rules := []tailcfg.FilterRule{
+		{
+			SrcIPs: []string{"100.64.0.1/32"},
+			CapGrant: []tailcfg.CapGrant{
+				{
+					Dsts: []netip.Prefix{
+						netip.MustParsePrefix("100.64.0.2/32"),
+						netip.MustParsePrefix("100.64.0.3/32"),
+					},
+					CapMap: tailcfg.PeerCapMap{
+						"example.com/cap/foo": nil,
+					},
+				},
+			},
+		},
+	}
+
+	node := &types.Node{
+		IPv4: iap("100.64.0.3"),
+	}
+
+	want := []tailcfg.FilterRule{
+		{
+			SrcIPs: []string{"100.64.0.1/32"},
+			CapGrant: []tailcfg.CapGrant{
+				{
+					Dsts: []netip.Prefix{
+						netip.MustParsePrefix("100.64.0.3/32"),
+					},
+					CapMap: tailcfg.PeerCapMap{
+						"example.com/cap/foo": nil,
+					},
+				},
+			},
+		},
+	}
+
+	got := ReduceFilterRules(node, rules)
+	if diff := cmp.Diff(want, got, util.Comparers...); diff != "" {
+		t.Errorf("ReduceFilterRules() unexpected result (-want +got):\n%s", diff)
+	}
+
+	other := &types.Node{
+		IPv4: iap("100.64.0.1"),
+	}
+
+	if got := ReduceFilterRules(other, rules); len(got) != 0 {
+		t.Errorf("ReduceFilterRules() expected no rules for source node, got %v", got)
+	}
+}
+
+func TestAppOnlyGrantPeers(t *testing.T) {
+	nodes := types.Nodes{
+		&types.Node{
+			ID:   1,
+			IPv4: iap("100.64.0.1"),
+			User: types.User{Name: "user1"},
+		},
+		&types.Node{
+			ID:   2,
+			IPv4: iap("100.64.0.2"),
+			User: types.User{Name: "user2"},
+		},
+		&types.Node{
+			ID:   3,
+			IPv4: iap("100.64.0.3"),
+			User: types.User{Name: "user3"},
+		},
+	}
+
+	pol, err := LoadACLPolicyFromBytes([]byte(`
+{
+	"grants": [
+		{
+			"src": ["user1"],
+			"dst": ["user2"],
+			"app": {
+				"example.com/cap/foo": [{"role": "admin"}],
+			},
+		},
+	],
+}`))
+	if err != nil {
+		t.Fatalf("LoadACLPolicyFromBytes() unexpected error: %s", err)
+	}
+
+	rules, err := pol.CompileFilterRules(nodes)
+	if err != nil {
+		t.Fatalf("CompileFilterRules() unexpected error: %s", err)
+	}
+
+	engine := NewEngine()
+	compiled, err := engine.Compile(pol, nodes, engine.Generation())
+	if err != nil {
+		t.Fatalf("Compile() unexpected error: %s", err)
+	}
+
+	peerIDs := func(peers types.Nodes) []types.NodeID {
+		var ids []types.NodeID
+		for _, peer := range peers {
+			ids = append(ids, peer.ID)
+		}
+
+		return ids
+	}
+
+	// The source and the destination of the app capability see each
+	// other, the node without a grant sees nobody.
+	want := map[types.NodeID][]types.NodeID{
+		1: {2},
+		2: {1},
+		3: nil,
+	}
+
+	for _, node := range nodes {
+		if diff := cmp.Diff(want[node.ID], peerIDs(FilterNodesByACL(node, nodes, rules))); diff != "" {
+			t.Errorf("FilterNodesByACL(%d) unexpected result (-want +got):\n%s", node.ID, diff)
+		}
+
+		if diff := cmp.Diff(want[node.ID], peerIDs(compiled.Peers(node, nodes))); diff != "" {
+			t.Errorf("CompiledPolicy.Peers(%d) unexpected result (-want +got):\n%s", node.ID, diff)
+		}
+	}
+}
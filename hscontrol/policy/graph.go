@@ -0,0 +1,218 @@
+package policy
+
+import (
+	"cmp"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/juanfont/headscale/hscontrol/util"
+	"go4.org/netipx"
+	"tailscale.com/tailcfg"
+	"tailscale.com/types/ipproto"
+)
+
+const (
+	VertexKindNode  = "node"
+	VertexKindRoute = "route"
+)
+
+// defaultProtocols are the protocols a rule without a protocol allows.
+var defaultProtocols = []ipproto.Proto{ipproto.TCP, ipproto.UDP, ipproto.ICMPv4, ipproto.ICMPv6}
+
+// AccessGraph is the effective access between the nodes of the tailnet,
+// see BuildAccessGraph.
+type AccessGraph struct {
+	Vertices []GraphVertex
+	Edges    []GraphEdge
+}
+
+// GraphVertex is a node, or a subnet route served by one or more nodes.
+type GraphVertex struct {
+	// ID is "node:<node ID>" for a node and "route:<prefix>" for a
+	// route.
+	ID   string
+	Kind string
+
+	// Name is the given name of a node, or the prefix of a route.
+	Name string
+	User string
+
+	// Addresses are the IP addresses of a node, or the prefix of a
+	// route.
+	Addresses []string
+
+	// Routers are the nodes serving a route.
+	Routers []string
+}
+
+// GraphEdge allows the node From to reach the node or route To on the
+// ports, formatted as in the policy, with the protocols.
+type GraphEdge struct {
+	From      string
+	To        string
+	Ports     []string
+	Protocols []string
+}
+
+// BuildAccessGraph returns who can reach whom with the policy. For every
+// node, the packet filter it receives is compiled and reduced like the
+// mapper does it, and its peers are found with FilterNodesByACL. An edge
+// is added from each peer matching the sources of a rule to the node, or
+// to the enabled route of the node, of its destinations.
+//
+// Subnet routes are only destinations, traffic from a subnet reaches the
+// tailnet with the address of its router.
+func BuildAccessGraph(pol *ACLPolicy, nodes types.Nodes) (*AccessGraph, error) {
+	graph := &AccessGraph{}
+	routes := make(map[netip.Prefix]int)
+
+	for _, node := range nodes {
+		graph.Vertices = append(graph.Vertices, GraphVertex{
+			ID:        nodeVertexID(node),
+			Kind:      VertexKindNode,
+			Name:      node.GivenName,
+			User:      node.User.Username(),
+			Addresses: node.IPsAsString(),
+		})
+	}
+
+	for _, node := range nodes {
+		for _, route := range node.Routes {
+			if !route.Enabled {
+				continue
+			}
+
+			index, ok := routes[route.Prefix]
+			if !ok {
+				index = len(graph.Vertices)
+				routes[route.Prefix] = index
+				graph.Vertices = append(graph.Vertices, GraphVertex{
+					ID:        routeVertexID(route.Prefix),
+					Kind:      VertexKindRoute,
+					Name:      route.Prefix.String(),
+					Addresses: []string{route.Prefix.String()},
+				})
+			}
+
+			graph.Vertices[index].Routers = append(graph.Vertices[index].Routers, node.GivenName)
+		}
+	}
+
+	edges := make(map[[3]string]*GraphEdge)
+	addEdge := func(from, to, protocols, ports string) {
+		key := [3]string{from, to, protocols}
+		edge, ok := edges[key]
+		if !ok {
+			edge = &GraphEdge{From: from, To: to}
+			if protocols != "" {
+				edge.Protocols = strings.Split(protocols, ",")
+			}
+			edges[key] = edge
+		}
+
+		if !slices.Contains(edge.Ports, ports) {
+			edge.Ports = append(edge.Ports, ports)
+		}
+	}
+
+	for _, node := range nodes {
+		rules, err := pol.CompileFilterRulesForNode(node, nodes)
+		if err != nil {
+			return nil, err
+		}
+
+		peers := FilterNodesByACL(node, nodes, rules)
+
+		for _, rule := range ReduceFilterRules(node, rules) {
+			srcs, err := ruleSources(rule)
+			if err != nil {
+				return nil, err
+			}
+
+			protocols := ruleProtocols(rule)
+
+			for _, peer := range peers {
+				if !peer.InIPSet(srcs) {
+					continue
+				}
+
+				for _, dst := range rule.DstPorts {
+					dsts, err := util.ParseIPSet(dst.IP, nil)
+					if err != nil {
+						continue
+					}
+
+					ports := portRangeString(dst.Ports)
+
+					if node.InIPSet(dsts) {
+						addEdge(nodeVertexID(peer), nodeVertexID(node), protocols, ports)
+					}
+
+					for _, route := range node.Routes {
+						if route.Enabled && dsts.OverlapsPrefix(route.Prefix) {
+							addEdge(nodeVertexID(peer), routeVertexID(route.Prefix), protocols, ports)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, edge := range edges {
+		slices.Sort(edge.Ports)
+		graph.Edges = append(graph.Edges, *edge)
+	}
+
+	slices.SortFunc(graph.Edges, func(a, b GraphEdge) int {
+		return cmp.Or(
+			cmp.Compare(a.From, b.From),
+			cmp.Compare(a.To, b.To),
+			slices.Compare(a.Protocols, b.Protocols),
+		)
+	})
+
+	return graph, nil
+}
+
+func nodeVertexID(node *types.Node) string {
+	return VertexKindNode + ":" + node.ID.String()
+}
+
+func routeVertexID(prefix netip.Prefix) string {
+	return VertexKindRoute + ":" + prefix.String()
+}
+
+func ruleSources(rule tailcfg.FilterRule) (*netipx.IPSet, error) {
+	var srcs netipx.IPSetBuilder
+	for _, src := range rule.SrcIPs {
+		set, err := util.ParseIPSet(src, nil)
+		if err != nil {
+			return nil, err
+		}
+		srcs.AddSet(set)
+	}
+
+	return srcs.IPSet()
+}
+
+// ruleProtocols returns the names of the protocols of the rule, joined
+// with commas.
+func ruleProtocols(rule tailcfg.FilterRule) string {
+	protocols := defaultProtocols
+	if len(rule.IPProto) > 0 {
+		protocols = make([]ipproto.Proto, 0, len(rule.IPProto))
+		for _, proto := range rule.IPProto {
+			protocols = append(protocols, ipproto.Proto(proto))
+		}
+	}
+
+	names := make([]string, 0, len(protocols))
+	for _, proto := range protocols {
+		name, _ := proto.MarshalText()
+		names = append(names, string(name))
+	}
+
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,69 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAccessGraph(t *testing.T) {
+	nodes := engineTestNodes()[:4]
+	pol := &ACLPolicy{
+		ACLs: []ACL{
+			{
+				Action:       "accept",
+				Sources:      []string{"user1"},
+				Destinations: []string{"tag:server:22,443"},
+				Protocol:     "tcp",
+			},
+			{
+				Action:       "accept",
+				Sources:      []string{"tag:server"},
+				Destinations: []string{"10.0.0.0/8:*"},
+			},
+		},
+	}
+
+	graph, err := BuildAccessGraph(pol, nodes)
+	require.NoError(t, err)
+
+	wantVertices := []GraphVertex{
+		{ID: "node:1", Kind: "node", Name: "laptop", User: "user1", Addresses: []string{"100.64.0.1", "fd7a:115c:a1e0::1"}},
+		{ID: "node:2", Kind: "node", Name: "phone", User: "user1", Addresses: []string{"100.64.0.2"}},
+		{ID: "node:3", Kind: "node", Name: "server", User: "user2", Addresses: []string{"100.64.0.3"}},
+		{ID: "node:4", Kind: "node", Name: "router", User: "user3", Addresses: []string{"100.64.0.4"}},
+		{ID: "route:10.0.0.0/8", Kind: "route", Name: "10.0.0.0/8", Addresses: []string{"10.0.0.0/8"}, Routers: []string{"router"}},
+	}
+	if diff := cmp.Diff(wantVertices, graph.Vertices); diff != "" {
+		t.Errorf("BuildAccessGraph() unexpected vertices (-want +got):\n%s", diff)
+	}
+
+	wantEdges := []GraphEdge{
+		{From: "node:1", To: "node:3", Ports: []string{"22", "443"}, Protocols: []string{"tcp"}},
+		{From: "node:2", To: "node:3", Ports: []string{"22", "443"}, Protocols: []string{"tcp"}},
+		{From: "node:3", To: "route:10.0.0.0/8", Ports: []string{"*"}, Protocols: []string{"tcp", "udp", "icmp", "ipv6-icmp"}},
+	}
+	if diff := cmp.Diff(wantEdges, graph.Edges); diff != "" {
+		t.Errorf("BuildAccessGraph() unexpected edges (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildAccessGraphNilPolicy(t *testing.T) {
+	nodes := types.Nodes{
+		&types.Node{ID: 1, IPv4: iap("100.64.0.1")},
+		&types.Node{ID: 2, IPv4: iap("100.64.0.2")},
+	}
+
+	graph, err := BuildAccessGraph(nil, nodes)
+	require.NoError(t, err)
+
+	want := []GraphEdge{
+		{From: "node:1", To: "node:2", Ports: []string{"*"}, Protocols: []string{"tcp", "udp", "icmp", "ipv6-icmp"}},
+		{From: "node:2", To: "node:1", Ports: []string{"*"}, Protocols: []string{"tcp", "udp", "icmp", "ipv6-icmp"}},
+	}
+	if diff := cmp.Diff(want, graph.Edges); diff != "" {
+		t.Errorf("BuildAccessGraph() unexpected edges (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,407 @@
+package policy
+
+import (
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"go4.org/netipx"
+	"tailscale.com/tailcfg"
+)
+
+// Checks of the policy linter, see LintFinding.
+const (
+	LintUnusedGroup       = "unused-group"
+	LintUnusedHost        = "unused-host"
+	LintUnusedTag         = "unused-tag"
+	LintShadowedRule      = "shadowed-rule"
+	LintUnknownUser       = "unknown-user"
+	LintUnknownTagOwner   = "unknown-tag-owner"
+	LintUnadvertisedRoute = "unadvertised-route"
+)
+
+const (
+	sectionGroups        = "groups"
+	sectionHosts         = "hosts"
+	sectionTagOwners     = "tagOwners"
+	sectionAutoApprovers = "autoApprovers"
+)
+
+// LintFinding is a problem found by Lint. Findings are warnings, a policy
+// with findings is still valid.
+type LintFinding struct {
+	// Check is the kind of problem, one of the Lint* constants.
+	Check string
+
+	// Section and Index locate the entry of the policy. Index is -1
+	// for the entries of sections that are maps, like groups.
+	Section string
+	Index   int
+
+	// Name is the group, host, tag, user or prefix the finding is
+	// about, or for a shadowed rule, the rule covering it.
+	Name    string
+	Message string
+}
+
+// Lint analyses the policy against the users and nodes, and reports:
+//   - groups, hosts and tags that are never used,
+//   - acls rules covered by an earlier rule,
+//   - members of groups that are not a user,
+//   - tagOwners that are not a user or a group,
+//   - autoApprovers routes that no node advertises.
+//
+// Rules are compared with the addresses of the current nodes, a rule is
+// only reported as shadowed if it is for these nodes.
+func (pol *ACLPolicy) Lint(users []types.User, nodes types.Nodes) []LintFinding {
+	if pol == nil {
+		return nil
+	}
+
+	var findings []LintFinding
+
+	findings = append(findings, pol.lintUnused()...)
+	findings = append(findings, pol.lintShadowedRules(nodes)...)
+	findings = append(findings, pol.lintUnknownUsers(users)...)
+	findings = append(findings, pol.lintAutoApprovers(nodes)...)
+
+	return findings
+}
+
+// references returns every alias the policy refers to, outside of the
+// definition of the alias itself.
+func (pol *ACLPolicy) references() map[string]bool {
+	refs := make(map[string]bool)
+	add := func(aliases ...string) {
+		for _, alias := range aliases {
+			refs[alias] = true
+		}
+	}
+	addDests := func(dests ...string) {
+		for _, dest := range dests {
+			if alias, _, err := parseDestination(dest); err == nil {
+				add(alias)
+			}
+		}
+	}
+
+	for _, acl := range pol.ACLs {
+		add(acl.Sources...)
+		addDests(acl.Destinations...)
+	}
+
+	for _, grant := range pol.Grants {
+		add(grant.Sources...)
+		add(grant.Destinations...)
+	}
+
+	for _, ssh := range pol.SSHs {
+		add(ssh.Sources...)
+		add(ssh.Destinations...)
+		add(ssh.Recorders...)
+	}
+
+	for _, attr := range pol.NodeAttrs {
+		add(attr.Targets...)
+	}
+
+	for _, test := range pol.Tests {
+		add(test.Source)
+		addDests(test.Accept...)
+		addDests(test.Deny...)
+	}
+
+	for _, approvers := range pol.AutoApprovers.Routes {
+		add(approvers...)
+	}
+	add(pol.AutoApprovers.ExitNode...)
+
+	for _, owners := range pol.TagOwners {
+		add(owners...)
+	}
+
+	for _, host := range pol.Hosts {
+		add(host...)
+	}
+
+	return refs
+}
+
+func (pol *ACLPolicy) lintUnused() []LintFinding {
+	var findings []LintFinding
+
+	refs := pol.references()
+
+	for _, group := range slices.Sorted(maps.Keys(pol.Groups)) {
+		if !refs[group] {
+			findings = append(findings, LintFinding{
+				Check:   LintUnusedGroup,
+				Section: sectionGroups,
+				Index:   -1,
+				Name:    group,
+				Message: fmt.Sprintf("group %q is never used", group),
+			})
+		}
+	}
+
+	for _, host := range slices.Sorted(maps.Keys(pol.Hosts)) {
+		if !refs[host] {
+			findings = append(findings, LintFinding{
+				Check:   LintUnusedHost,
+				Section: sectionHosts,
+				Index:   -1,
+				Name:    host,
+				Message: fmt.Sprintf("host %q is never used", host),
+			})
+		}
+	}
+
+	for _, tag := range slices.Sorted(maps.Keys(pol.TagOwners)) {
+		if !refs[tag] {
+			findings = append(findings, LintFinding{
+				Check:   LintUnusedTag,
+				Section: sectionTagOwners,
+				Index:   -1,
+				Name:    tag,
+				Message: fmt.Sprintf("tag %q is never used", tag),
+			})
+		}
+	}
+
+	return findings
+}
+
+func (pol *ACLPolicy) lintUnknownUsers(users []types.User) []LintFinding {
+	var findings []LintFinding
+
+	known := func(name string) bool {
+		return slices.ContainsFunc(users, func(user types.User) bool {
+			return user.Username() == name
+		})
+	}
+
+	for _, group := range slices.Sorted(maps.Keys(pol.Groups)) {
+		for _, member := range pol.Groups[group] {
+			if isOIDCGroup(member) || known(member) {
+				continue
+			}
+
+			findings = append(findings, LintFinding{
+				Check:   LintUnknownUser,
+				Section: sectionGroups,
+				Index:   -1,
+				Name:    member,
+				Message: fmt.Sprintf("user %q of group %q does not exist", member, group),
+			})
+		}
+	}
+
+	for _, tag := range slices.Sorted(maps.Keys(pol.TagOwners)) {
+		for _, owner := range pol.TagOwners[tag] {
+			if isGroup(owner) {
+				if _, ok := pol.Groups[owner]; ok || isOIDCGroup(owner) {
+					continue
+				}
+			} else if isTag(owner) || known(owner) {
+				continue
+			}
+
+			findings = append(findings, LintFinding{
+				Check:   LintUnknownTagOwner,
+				Section: sectionTagOwners,
+				Index:   -1,
+				Name:    owner,
+				Message: fmt.Sprintf("owner %q of tag %q is not a user or a group", owner, tag),
+			})
+		}
+	}
+
+	return findings
+}
+
+// lintAutoApprovers reports the routes of autoApprovers that do not
+// contain a route advertised by any node.
+func (pol *ACLPolicy) lintAutoApprovers(nodes types.Nodes) []LintFinding {
+	var findings []LintFinding
+
+	for _, route := range slices.Sorted(maps.Keys(pol.AutoApprovers.Routes)) {
+		prefix, err := parseAutoApprovedPrefix(route)
+		if err != nil {
+			continue
+		}
+
+		advertised := slices.ContainsFunc(nodes, func(node *types.Node) bool {
+			return node.Hostinfo != nil && slices.ContainsFunc(
+				node.Hostinfo.RoutableIPs,
+				func(routable netip.Prefix) bool {
+					return prefix.Bits() <= routable.Bits() && prefix.Contains(routable.Addr())
+				},
+			)
+		})
+		if !advertised {
+			findings = append(findings, LintFinding{
+				Check:   LintUnadvertisedRoute,
+				Section: sectionAutoApprovers,
+				Index:   -1,
+				Name:    route,
+				Message: fmt.Sprintf("no node advertises a route in %s", route),
+			})
+		}
+	}
+
+	return findings
+}
+
+// lintRule is an acls rule expanded for the current nodes.
+type lintRule struct {
+	srcs      *netipx.IPSet
+	protocols []int
+	dests     []lintDest
+}
+
+type lintDest struct {
+	ips   *netipx.IPSet
+	ports []tailcfg.PortRange
+}
+
+// lintShadowedRules reports the acls rules whose sources, protocols and
+// destinations are all allowed by a single earlier rule.
+func (pol *ACLPolicy) lintShadowedRules(nodes types.Nodes) []LintFinding {
+	var findings []LintFinding
+
+	rules := make([]*lintRule, len(pol.ACLs))
+	for index, acl := range pol.ACLs {
+		rules[index] = pol.expandLintRule(acl, nodes)
+	}
+
+	for index, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		for earlier := range index {
+			if rules[earlier] == nil || !rules[earlier].covers(rule) {
+				continue
+			}
+
+			findings = append(findings, LintFinding{
+				Check:   LintShadowedRule,
+				Section: sectionACLs,
+				Index:   index,
+				Name:    fmt.Sprintf("%s[%d]", sectionACLs, earlier),
+				Message: fmt.Sprintf("rule %d is covered by rule %d", index, earlier),
+			})
+
+			break
+		}
+	}
+
+	return findings
+}
+
+// expandLintRule expands an acls rule, or returns nil if it cannot be
+// compared to other rules: rules with a posture or autogroup:self depend
+// on the source or destination node, and rules matching no address would
+// be covered by any rule.
+func (pol *ACLPolicy) expandLintRule(acl ACL, nodes types.Nodes) *lintRule {
+	if len(acl.SrcPosture) > 0 {
+		return nil
+	}
+
+	protocols, isWildcard, err := parseProtocol(acl.Protocol)
+	if err != nil {
+		return nil
+	}
+
+	// Rules without a protocol allow the default protocols of the
+	// Tailscale packet filter.
+	if len(protocols) == 0 {
+		protocols = []int{protocolTCP, protocolUDP, protocolICMP, protocolIPv6ICMP}
+	}
+
+	var srcs netipx.IPSetBuilder
+	for _, src := range acl.Sources {
+		expanded, err := pol.ExpandAlias(nodes, src)
+		if err != nil {
+			return nil
+		}
+		srcs.AddSet(expanded)
+	}
+
+	rule := &lintRule{protocols: protocols}
+
+	rule.srcs, err = srcs.IPSet()
+	if err != nil || len(rule.srcs.Prefixes()) == 0 {
+		return nil
+	}
+
+	for _, dest := range acl.Destinations {
+		alias, port, err := parseDestination(dest)
+		if err != nil || isAutoGroupSelf(alias) {
+			return nil
+		}
+
+		ips, err := pol.ExpandAlias(nodes, alias)
+		if err != nil {
+			return nil
+		}
+
+		ports, err := expandPorts(port, isWildcard)
+		if err != nil {
+			return nil
+		}
+
+		if len(ips.Prefixes()) > 0 {
+			rule.dests = append(rule.dests, lintDest{ips: ips, ports: *ports})
+		}
+	}
+
+	if len(rule.dests) == 0 {
+		return nil
+	}
+
+	return rule
+}
+
+// covers reports if every source, protocol, destination and port of other
+// is allowed by the rule.
+func (rule *lintRule) covers(other *lintRule) bool {
+	if !ipSetContains(rule.srcs, other.srcs) {
+		return false
+	}
+
+	for _, proto := range other.protocols {
+		if !slices.Contains(rule.protocols, proto) {
+			return false
+		}
+	}
+
+	for _, dest := range other.dests {
+		for _, port := range dest.ports {
+			covered := slices.ContainsFunc(rule.dests, func(ruleDest lintDest) bool {
+				return ipSetContains(ruleDest.ips, dest.ips) &&
+					slices.ContainsFunc(ruleDest.ports, func(rulePort tailcfg.PortRange) bool {
+						return rulePort.First <= port.First && port.Last <= rulePort.Last
+					})
+			})
+			if !covered {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// ipSetContains reports if every address of inner is in outer.
+func ipSetContains(outer, inner *netipx.IPSet) bool {
+	var rest netipx.IPSetBuilder
+	rest.AddSet(inner)
+	rest.RemoveSet(outer)
+
+	set, err := rest.IPSet()
+
+	return err == nil && len(set.Prefixes()) == 0
+}
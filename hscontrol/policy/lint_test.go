@@ -0,0 +1,233 @@
+package policy
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"tailscale.com/tailcfg"
+)
+
+func TestLint(t *testing.T) {
+	users := []types.User{{Name: "user1"}, {Name: "user2"}}
+	nodes := types.Nodes{
+		&types.Node{
+			IPv4: iap("100.64.0.1"),
+			User: users[0],
+		},
+		&types.Node{
+			IPv4: iap("100.64.0.2"),
+			User: users[1],
+			Hostinfo: &tailcfg.Hostinfo{
+				RoutableIPs: []netip.Prefix{netip.MustParsePrefix("10.1.0.0/24")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		pol  *ACLPolicy
+		want []LintFinding
+	}{
+		{
+			name: "nil-policy",
+			pol:  nil,
+			want: nil,
+		},
+		{
+			name: "clean",
+			pol: &ACLPolicy{
+				Groups: Groups{
+					"group:admins": {"user1"},
+				},
+				Hosts: Hosts{
+					"office": {"10.1.0.0/24"},
+				},
+				TagOwners: TagOwners{
+					"tag:router": {"group:admins"},
+				},
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"group:admins"},
+						Destinations: []string{"office:*", "tag:router:22"},
+					},
+					{
+						Action:       "accept",
+						Sources:      []string{"user2"},
+						Destinations: []string{"user1:*"},
+					},
+				},
+				AutoApprovers: AutoApprovers{
+					Routes: map[string][]string{
+						"10.1.0.0/16": {"tag:router"},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "unused",
+			pol: &ACLPolicy{
+				Groups: Groups{
+					"group:admins": {"user1"},
+					"group:unused": {"user2"},
+				},
+				Hosts: Hosts{
+					"unused": {"10.1.0.0/24"},
+				},
+				TagOwners: TagOwners{
+					"tag:unused": {"user1"},
+				},
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"group:admins"},
+						Destinations: []string{"*:*"},
+					},
+				},
+			},
+			want: []LintFinding{
+				{
+					Check:   LintUnusedGroup,
+					Section: "groups",
+					Index:   -1,
+					Name:    "group:unused",
+					Message: `group "group:unused" is never used`,
+				},
+				{
+					Check:   LintUnusedHost,
+					Section: "hosts",
+					Index:   -1,
+					Name:    "unused",
+					Message: `host "unused" is never used`,
+				},
+				{
+					Check:   LintUnusedTag,
+					Section: "tagOwners",
+					Index:   -1,
+					Name:    "tag:unused",
+					Message: `tag "tag:unused" is never used`,
+				},
+			},
+		},
+		{
+			name: "shadowed-rules",
+			pol: &ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"user1"},
+						Destinations: []string{"100.64.0.0/24:22,80-90"},
+					},
+					// Covered by the first rule.
+					{
+						Action:       "accept",
+						Sources:      []string{"100.64.0.1"},
+						Destinations: []string{"user2:85"},
+						Protocol:     "tcp",
+					},
+					// Port 443 is not covered.
+					{
+						Action:       "accept",
+						Sources:      []string{"user1"},
+						Destinations: []string{"user2:22,443"},
+					},
+					// Sources are broader than the first rule.
+					{
+						Action:       "accept",
+						Sources:      []string{"*"},
+						Destinations: []string{"user2:22"},
+					},
+					// Covered by the previous rule.
+					{
+						Action:       "accept",
+						Sources:      []string{"user2"},
+						Destinations: []string{"100.64.0.2:22"},
+						Protocol:     "tcp",
+					},
+				},
+			},
+			want: []LintFinding{
+				{
+					Check:   LintShadowedRule,
+					Section: "acls",
+					Index:   1,
+					Name:    "acls[0]",
+					Message: "rule 1 is covered by rule 0",
+				},
+				{
+					Check:   LintShadowedRule,
+					Section: "acls",
+					Index:   4,
+					Name:    "acls[3]",
+					Message: "rule 4 is covered by rule 3",
+				},
+			},
+		},
+		{
+			name: "unknown-users-and-routes",
+			pol: &ACLPolicy{
+				Groups: Groups{
+					"group:admins": {"user1", "ghost", "group:oidc:admins"},
+				},
+				TagOwners: TagOwners{
+					"tag:router": {"group:admins", "group:missing", "nobody"},
+				},
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"group:admins"},
+						Destinations: []string{"tag:router:*"},
+					},
+				},
+				AutoApprovers: AutoApprovers{
+					Routes: map[string][]string{
+						"10.1.0.0/24": {"tag:router"},
+						"10.2.0.0/16": {"tag:router"},
+					},
+				},
+			},
+			want: []LintFinding{
+				{
+					Check:   LintUnknownUser,
+					Section: "groups",
+					Index:   -1,
+					Name:    "ghost",
+					Message: `user "ghost" of group "group:admins" does not exist`,
+				},
+				{
+					Check:   LintUnknownTagOwner,
+					Section: "tagOwners",
+					Index:   -1,
+					Name:    "group:missing",
+					Message: `owner "group:missing" of tag "tag:router" is not a user or a group`,
+				},
+				{
+					Check:   LintUnknownTagOwner,
+					Section: "tagOwners",
+					Index:   -1,
+					Name:    "nobody",
+					Message: `owner "nobody" of tag "tag:router" is not a user or a group`,
+				},
+				{
+					Check:   LintUnadvertisedRoute,
+					Section: "autoApprovers",
+					Index:   -1,
+					Name:    "10.2.0.0/16",
+					Message: "no node advertises a route in 10.2.0.0/16",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pol.Lint(users, nodes)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Lint() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
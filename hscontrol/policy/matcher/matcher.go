@@ -13,12 +13,21 @@ type Match struct {
 	Dests *netipx.IPSet
 }
 
+// MatchFromFilterRule returns the sources and destinations of the rule.
+// The destinations of a CapGrant are destinations too, a source granted
+// an app capability on a node needs to see it as a peer.
 func MatchFromFilterRule(rule tailcfg.FilterRule) Match {
 	dests := []string{}
 	for _, dest := range rule.DstPorts {
 		dests = append(dests, dest.IP)
 	}
 
+	for _, capGrant := range rule.CapGrant {
+		for _, dest := range capGrant.Dsts {
+			dests = append(dests, dest.String())
+		}
+	}
+
 	return MatchFromStrings(rule.SrcIPs, dests)
 }
 
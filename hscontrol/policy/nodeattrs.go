@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"tailscale.com/tailcfg"
+)
+
+var ErrInvalidNodeAttr = errors.New("node attribute must have a target and at least one attribute")
+
+// CompileNodeCapMap returns the CapMap of the node, starting with base and
+// applying the nodeAttrs section of the policy in order. Attributes are
+// added before the removals of the same entry are applied.
+func (pol *ACLPolicy) CompileNodeCapMap(
+	node *types.Node,
+	base tailcfg.NodeCapMap,
+) (tailcfg.NodeCapMap, error) {
+	capMap := maps.Clone(base)
+	if pol == nil || len(pol.NodeAttrs) == 0 {
+		return capMap, nil
+	}
+
+	if capMap == nil {
+		capMap = tailcfg.NodeCapMap{}
+	}
+
+	for index, attr := range pol.NodeAttrs {
+		if len(attr.Targets) == 0 || len(attr.Attributes)+len(attr.RemoveAttributes) == 0 {
+			return nil, fmt.Errorf("parsing policy, node attribute index: %d: %w", index, ErrInvalidNodeAttr)
+		}
+
+		matches, err := pol.nodeMatchesTargets(node, attr.Targets)
+		if err != nil {
+			return nil, fmt.Errorf("parsing policy, node attribute index: %d: %w", index, err)
+		}
+
+		if !matches {
+			continue
+		}
+
+		for _, name := range attr.Attributes {
+			if _, ok := capMap[tailcfg.NodeCapability(name)]; !ok {
+				capMap[tailcfg.NodeCapability(name)] = []tailcfg.RawMessage{}
+			}
+		}
+
+		for _, name := range attr.RemoveAttributes {
+			delete(capMap, tailcfg.NodeCapability(name))
+		}
+	}
+
+	return capMap, nil
+}
+
+// nodeMatchesTargets reports if the node is part of any of the targets,
+// which can be anything that can be used as a source in the policy.
+func (pol *ACLPolicy) nodeMatchesTargets(node *types.Node, targets []string) (bool, error) {
+	for _, target := range targets {
+		ips, err := pol.ExpandAlias(types.Nodes{node}, target)
+		// Tags without owners can only be expanded if a node
+		// has them forced, which is not an error for other nodes.
+		if errors.Is(err, ErrInvalidTag) {
+			continue
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if node.InIPSet(ips) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
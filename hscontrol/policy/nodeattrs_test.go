@@ -0,0 +1,157 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"tailscale.com/tailcfg"
+)
+
+func TestCompileNodeCapMap(t *testing.T) {
+	base := tailcfg.NodeCapMap{
+		tailcfg.CapabilityFileSharing: []tailcfg.RawMessage{},
+		tailcfg.CapabilityAdmin:       []tailcfg.RawMessage{},
+		tailcfg.CapabilitySSH:         []tailcfg.RawMessage{},
+	}
+
+	user1 := &types.Node{
+		ID:   1,
+		IPv4: iap("100.64.0.1"),
+		User: types.User{Name: "user1"},
+	}
+
+	server := &types.Node{
+		ID:         2,
+		IPv4:       iap("100.64.0.2"),
+		User:       types.User{Name: "user2"},
+		ForcedTags: []string{"tag:server"},
+	}
+
+	pol := &ACLPolicy{
+		Groups: Groups{
+			"group:restricted": []string{"user1"},
+		},
+		NodeAttrs: []NodeAttr{
+			{
+				Targets:          []string{"group:restricted"},
+				RemoveAttributes: []string{string(tailcfg.CapabilityFileSharing)},
+			},
+			{
+				Targets:    []string{"tag:server"},
+				Attributes: []string{string(tailcfg.NodeAttrRandomizeClientPort)},
+			},
+			{
+				Targets:    []string{"*"},
+				Attributes: []string{"nextdns:abc123", "nextdns:no-device-info"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		pol     *ACLPolicy
+		node    *types.Node
+		want    tailcfg.NodeCapMap
+		wantErr bool
+	}{
+		{
+			name: "no-policy",
+			node: user1,
+			want: base,
+		},
+		{
+			name: "remove-from-group",
+			pol:  pol,
+			node: user1,
+			want: tailcfg.NodeCapMap{
+				tailcfg.CapabilityAdmin:  []tailcfg.RawMessage{},
+				tailcfg.CapabilitySSH:    []tailcfg.RawMessage{},
+				"nextdns:abc123":         []tailcfg.RawMessage{},
+				"nextdns:no-device-info": []tailcfg.RawMessage{},
+			},
+		},
+		{
+			name: "add-to-tag",
+			pol:  pol,
+			node: server,
+			want: tailcfg.NodeCapMap{
+				tailcfg.CapabilityFileSharing:       []tailcfg.RawMessage{},
+				tailcfg.CapabilityAdmin:             []tailcfg.RawMessage{},
+				tailcfg.CapabilitySSH:               []tailcfg.RawMessage{},
+				tailcfg.NodeAttrRandomizeClientPort: []tailcfg.RawMessage{},
+				"nextdns:abc123":                    []tailcfg.RawMessage{},
+				"nextdns:no-device-info":            []tailcfg.RawMessage{},
+			},
+		},
+		{
+			name: "missing-target",
+			pol: &ACLPolicy{
+				NodeAttrs: []NodeAttr{
+					{
+						Attributes: []string{"funnel"},
+					},
+				},
+			},
+			node:    user1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.pol.CompileNodeCapMap(tt.node, base)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompileNodeCapMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CompileNodeCapMap() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if len(base) != 3 {
+		t.Errorf("CompileNodeCapMap() modified the base CapMap: %v", base)
+	}
+}
+
+func TestLoadPolicyWithOnlyNodeAttrsOrSSH(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr error
+	}{
+		{
+			name: "only-node-attrs",
+			policy: `{
+				"nodeAttrs": [
+					{"target": ["*"], "attr": ["funnel"]},
+				],
+			}`,
+		},
+		{
+			name: "only-ssh",
+			policy: `{
+				"ssh": [
+					{"action": "accept", "src": ["autogroup:member"], "dst": ["autogroup:self"], "users": ["autogroup:nonroot"]},
+				],
+			}`,
+		},
+		{
+			name:    "empty",
+			policy:  `{}`,
+			wantErr: ErrEmptyPolicy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadACLPolicyFromBytes([]byte(tt.policy))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LoadACLPolicyFromBytes() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/juanfont/headscale/hscontrol/util"
+	"go4.org/netipx"
+	"tailscale.com/tailcfg"
+	"tailscale.com/util/cmpver"
+)
+
+var (
+	ErrInvalidPosture          = errors.New("invalid posture")
+	ErrInvalidPostureCondition = errors.New("invalid posture condition")
+)
+
+const (
+	postureAttrOS             = "node:os"
+	postureAttrTSVersion      = "node:tsVersion"
+	postureAttrKeyExpiryValid = "node:keyExpiryValid"
+)
+
+// postureCondition is a parsed condition of a posture, e.g.
+// "node:tsVersion >= '1.60'".
+type postureCondition struct {
+	attr   string
+	op     string
+	values []string
+}
+
+// parsePostureCondition parses a condition of the form
+// "<attribute> <operator> <value>". The value is a quoted string, a list
+// of quoted strings for IN and NOT IN, or true or false.
+func parsePostureCondition(cond string) (*postureCondition, error) {
+	invalid := fmt.Errorf("%w: %q", ErrInvalidPostureCondition, cond)
+
+	attr, rest, ok := strings.Cut(strings.TrimSpace(cond), " ")
+	if !ok {
+		return nil, invalid
+	}
+
+	rest = strings.TrimSpace(rest)
+	op, value, ok := strings.Cut(rest, " ")
+	if !ok {
+		return nil, invalid
+	}
+
+	if op == "NOT" {
+		var in string
+		in, value, ok = strings.Cut(strings.TrimSpace(value), " ")
+		if !ok || in != "IN" {
+			return nil, invalid
+		}
+
+		op = "NOT IN"
+	}
+
+	var values []string
+	value = strings.TrimSpace(value)
+	switch {
+	case op == "IN" || op == "NOT IN":
+		if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+			return nil, invalid
+		}
+
+		for _, v := range strings.Split(strings.Trim(value, "[]"), ",") {
+			unquoted, err := unquotePostureValue(v)
+			if err != nil {
+				return nil, invalid
+			}
+
+			values = append(values, unquoted)
+		}
+	case value == "true" || value == "false":
+		values = []string{value}
+	default:
+		unquoted, err := unquotePostureValue(value)
+		if err != nil {
+			return nil, invalid
+		}
+
+		values = []string{unquoted}
+	}
+
+	switch attr {
+	case postureAttrOS:
+		if !slices.Contains([]string{"==", "!=", "IN", "NOT IN"}, op) {
+			return nil, invalid
+		}
+	case postureAttrTSVersion:
+		if !slices.Contains([]string{"==", "!=", "<", "<=", ">", ">=", "IN", "NOT IN"}, op) {
+			return nil, invalid
+		}
+	case postureAttrKeyExpiryValid:
+		if op != "==" && op != "!=" {
+			return nil, invalid
+		}
+
+		if _, err := strconv.ParseBool(values[0]); err != nil {
+			return nil, invalid
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown attribute %q", ErrInvalidPostureCondition, attr)
+	}
+
+	return &postureCondition{
+		attr:   attr,
+		op:     op,
+		values: values,
+	}, nil
+}
+
+func unquotePostureValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 {
+		return "", ErrInvalidPostureCondition
+	}
+
+	if (value[0] == '\'' && value[len(value)-1] == '\'') ||
+		(value[0] == '"' && value[len(value)-1] == '"') {
+		return value[1 : len(value)-1], nil
+	}
+
+	return "", ErrInvalidPostureCondition
+}
+
+// matches reports if the node meets the condition. A node that does not
+// report the attribute, e.g. without Hostinfo, does not meet it.
+func (c *postureCondition) matches(node *types.Node, now time.Time) bool {
+	switch c.attr {
+	case postureAttrOS:
+		var os string
+		if node.Hostinfo != nil {
+			os = node.Hostinfo.OS
+		}
+
+		if os == "" {
+			return false
+		}
+
+		in := slices.ContainsFunc(c.values, func(v string) bool {
+			return strings.EqualFold(v, os)
+		})
+
+		return in == (c.op == "==" || c.op == "IN")
+	case postureAttrTSVersion:
+		var version string
+		if node.Hostinfo != nil {
+			// Drop the build suffix, "1.66.4-t1234abcd-g5678".
+			version, _, _ = strings.Cut(node.Hostinfo.IPNVersion, "-")
+		}
+
+		if version == "" {
+			return false
+		}
+
+		switch c.op {
+		case "IN", "NOT IN":
+			in := slices.ContainsFunc(c.values, func(v string) bool {
+				return cmpver.Compare(version, v) == 0
+			})
+
+			return in == (c.op == "IN")
+		}
+
+		cmp := cmpver.Compare(version, c.values[0])
+		switch c.op {
+		case "==":
+			return cmp == 0
+		case "!=":
+			return cmp != 0
+		case "<":
+			return cmp < 0
+		case "<=":
+			return cmp <= 0
+		case ">":
+			return cmp > 0
+		case ">=":
+			return cmp >= 0
+		}
+	case postureAttrKeyExpiryValid:
+		valid := node.Expiry != nil && !node.Expiry.IsZero() && node.Expiry.After(now)
+		want, _ := strconv.ParseBool(c.values[0])
+
+		return (valid == want) == (c.op == "==")
+	}
+
+	return false
+}
+
+// nodeMeetsPostures reports if the node meets all conditions of at least
+// one of the named postures.
+func nodeMeetsPostures(
+	node *types.Node,
+	postures [][]*postureCondition,
+	now time.Time,
+) bool {
+	for _, conds := range postures {
+		ok := true
+		for _, cond := range conds {
+			if !cond.matches(node, now) {
+				ok = false
+
+				break
+			}
+		}
+
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// srcPostures returns the parsed postures that apply to the sources of a
+// rule, the ones of the rule itself or the default ones of the policy.
+func (pol *ACLPolicy) srcPostures(names []string) ([][]*postureCondition, error) {
+	if len(names) == 0 {
+		names = pol.DefaultSrcPosture
+	}
+
+	var postures [][]*postureCondition
+	for _, name := range names {
+		conditions, ok := pol.Postures[name]
+		if !ok || !strings.HasPrefix(name, "posture:") {
+			return nil, fmt.Errorf("%w: %q is not defined", ErrInvalidPosture, name)
+		}
+
+		var conds []*postureCondition
+		for _, condition := range conditions {
+			cond, err := parsePostureCondition(condition)
+			if err != nil {
+				return nil, fmt.Errorf("%w, posture %q", err, name)
+			}
+
+			conds = append(conds, cond)
+		}
+
+		postures = append(postures, conds)
+	}
+
+	return postures, nil
+}
+
+// UsesKeyExpiry reports if a posture of the policy depends on the key
+// expiry of the nodes, in which case the packet filters change when a
+// key expires.
+func (pol *ACLPolicy) UsesKeyExpiry() bool {
+	if pol == nil {
+		return false
+	}
+
+	for _, conditions := range pol.Postures {
+		for _, condition := range conditions {
+			cond, err := parsePostureCondition(condition)
+			if err == nil && cond.attr == postureAttrKeyExpiryValid {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// applySrcPosture removes the addresses of the nodes that do not meet the
+// source postures of a rule from the SrcIPs of its FilterRules. Rules
+// without any source left are dropped.
+func (pol *ACLPolicy) applySrcPosture(
+	names []string,
+	nodes types.Nodes,
+	rules []tailcfg.FilterRule,
+) ([]tailcfg.FilterRule, error) {
+	postures, err := pol.srcPostures(names)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(postures) == 0 {
+		return rules, nil
+	}
+
+	now := time.Now()
+
+	var failing netipx.IPSetBuilder
+	for _, node := range nodes {
+		if !nodeMeetsPostures(node, postures, now) {
+			node.AppendToIPSet(&failing)
+		}
+	}
+
+	failingSet, err := failing.IPSet()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(failingSet.Prefixes()) == 0 {
+		return rules, nil
+	}
+
+	ret := make([]tailcfg.FilterRule, 0, len(rules))
+	for _, rule := range rules {
+		var srcs netipx.IPSetBuilder
+		for _, srcIP := range rule.SrcIPs {
+			set, err := util.ParseIPSet(srcIP, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			srcs.AddSet(set)
+		}
+
+		srcs.RemoveSet(failingSet)
+		srcSet, err := srcs.IPSet()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(srcSet.Prefixes()) == 0 {
+			continue
+		}
+
+		rule.SrcIPs = nil
+		for _, prefix := range srcSet.Prefixes() {
+			rule.SrcIPs = append(rule.SrcIPs, prefix.String())
+		}
+
+		ret = append(ret, rule)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,275 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"tailscale.com/tailcfg"
+)
+
+func TestPostureConditions(t *testing.T) {
+	now := time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)
+	future := now.Add(24 * time.Hour)
+	past := now.Add(-24 * time.Hour)
+
+	node := &types.Node{
+		Hostinfo: &tailcfg.Hostinfo{
+			OS:         "macOS",
+			IPNVersion: "1.66.4-t1234abcd-g5678",
+		},
+		Expiry: &future,
+	}
+
+	tests := []struct {
+		cond    string
+		node    *types.Node
+		want    bool
+		wantErr bool
+	}{
+		{cond: "node:os == 'macos'", node: node, want: true},
+		{cond: "node:os != 'macos'", node: node, want: false},
+		{cond: "node:os IN ['linux', 'macos']", node: node, want: true},
+		{cond: "node:os NOT IN ['linux', \"windows\"]", node: node, want: true},
+		{cond: "node:tsVersion >= '1.60'", node: node, want: true},
+		{cond: "node:tsVersion >= '1.66.5'", node: node, want: false},
+		{cond: "node:tsVersion < '1.70.0'", node: node, want: true},
+		{cond: "node:tsVersion == '1.66.4'", node: node, want: true},
+		{cond: "node:keyExpiryValid == true", node: node, want: true},
+		{cond: "node:keyExpiryValid == true", node: &types.Node{Expiry: &past}, want: false},
+		{cond: "node:keyExpiryValid == true", node: &types.Node{}, want: false},
+		{cond: "node:keyExpiryValid != true", node: &types.Node{}, want: true},
+		{cond: "node:tsVersion >= '1.60'", node: &types.Node{}, want: false},
+		{cond: "node:os == 'linux'", node: &types.Node{}, want: false},
+		{cond: "node:os >= 'linux'", wantErr: true},
+		{cond: "node:keyExpiryValid == 'yes'", wantErr: true},
+		{cond: "node:unknown == 'x'", wantErr: true},
+		{cond: "node:os == linux", wantErr: true},
+		{cond: "node:os", wantErr: true},
+		{cond: "node:os IN 'linux'", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cond, func(t *testing.T) {
+			cond, err := parsePostureCondition(tt.cond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePostureCondition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got := cond.matches(tt.node, now); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileFilterRulesPosture(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+
+	nodes := types.Nodes{
+		&types.Node{
+			ID:   1,
+			IPv4: iap("100.64.0.1"),
+			User: types.User{Name: "user1"},
+			Hostinfo: &tailcfg.Hostinfo{
+				OS:         "linux",
+				IPNVersion: "1.72.0",
+			},
+			Expiry: &future,
+		},
+		&types.Node{
+			ID:   2,
+			IPv4: iap("100.64.0.2"),
+			User: types.User{Name: "user1"},
+			Hostinfo: &tailcfg.Hostinfo{
+				OS:         "linux",
+				IPNVersion: "1.50.0",
+			},
+		},
+		&types.Node{
+			ID:   3,
+			IPv4: iap("100.64.0.3"),
+			User: types.User{Name: "user2"},
+			Hostinfo: &tailcfg.Hostinfo{
+				OS:         "windows",
+				IPNVersion: "1.72.0",
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		pol     ACLPolicy
+		want    []tailcfg.FilterRule
+		wantErr bool
+	}{
+		{
+			name: "outdated-node-is-excluded",
+			pol: ACLPolicy{
+				Postures: Postures{
+					"posture:latest": []string{"node:tsVersion >= '1.60'"},
+				},
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"user1"},
+						Destinations: []string{"10.0.0.0/8:*"},
+						SrcPosture:   []string{"posture:latest"},
+					},
+				},
+			},
+			want: []tailcfg.FilterRule{
+				{
+					SrcIPs: []string{"100.64.0.1/32"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "10.0.0.0/8", Ports: tailcfg.PortRangeAny},
+					},
+				},
+			},
+		},
+		{
+			name: "default-posture-any-of",
+			pol: ACLPolicy{
+				Postures: Postures{
+					"posture:linux":   []string{"node:os == 'linux'", "node:keyExpiryValid == true"},
+					"posture:windows": []string{"node:os == 'windows'"},
+				},
+				DefaultSrcPosture: []string{"posture:linux", "posture:windows"},
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"user1", "user2"},
+						Destinations: []string{"10.0.0.0/8:*"},
+					},
+				},
+			},
+			want: []tailcfg.FilterRule{
+				{
+					SrcIPs: []string{"100.64.0.1/32", "100.64.0.3/32"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "10.0.0.0/8", Ports: tailcfg.PortRangeAny},
+					},
+				},
+			},
+		},
+		{
+			name: "no-source-left",
+			pol: ACLPolicy{
+				Postures: Postures{
+					"posture:mac": []string{"node:os == 'macos'"},
+				},
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"user1"},
+						Destinations: []string{"10.0.0.0/8:*"},
+						SrcPosture:   []string{"posture:mac"},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "grant-posture",
+			pol: ACLPolicy{
+				Postures: Postures{
+					"posture:windows": []string{"node:os == 'windows'"},
+				},
+				Grants: []Grant{
+					{
+						Sources:      []string{"user1", "user2"},
+						Destinations: []string{"10.0.0.0/8"},
+						IP:           []string{"tcp:443"},
+						SrcPosture:   []string{"posture:windows"},
+					},
+				},
+			},
+			want: []tailcfg.FilterRule{
+				{
+					SrcIPs: []string{"100.64.0.3/32"},
+					DstPorts: []tailcfg.NetPortRange{
+						{IP: "10.0.0.0/8", Ports: tailcfg.PortRange{First: 443, Last: 443}},
+					},
+					IPProto: []int{protocolTCP},
+				},
+			},
+		},
+		{
+			name: "undefined-posture",
+			pol: ACLPolicy{
+				ACLs: []ACL{
+					{
+						Action:       "accept",
+						Sources:      []string{"*"},
+						Destinations: []string{"*:*"},
+						SrcPosture:   []string{"posture:missing"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.pol.CompileFilterRules(nodes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompileFilterRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CompileFilterRules() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUsesKeyExpiry(t *testing.T) {
+	tests := []struct {
+		name string
+		pol  *ACLPolicy
+		want bool
+	}{
+		{
+			name: "no-policy",
+			pol:  nil,
+			want: false,
+		},
+		{
+			name: "os-posture",
+			pol: &ACLPolicy{
+				Postures: Postures{
+					"posture:mac": {"node:os == 'macos'"},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "key-expiry-posture",
+			pol: &ACLPolicy{
+				Postures: Postures{
+					"posture:mac":   {"node:os == 'macos'"},
+					"posture:valid": {"node:os == 'linux'", "node:keyExpiryValid == true"},
+				},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pol.UsesKeyExpiry(); got != tt.want {
+				t.Errorf("UsesKeyExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
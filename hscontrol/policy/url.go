@@ -0,0 +1,129 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/juanfont/headscale/hscontrol/types"
+)
+
+var (
+	ErrPolicyFetch    = errors.New("unexpected response fetching policy")
+	ErrPolicyTooLarge = errors.New("policy is too large")
+)
+
+const maxPolicySize = 4 << 20
+
+// URLLoader fetches a HuJSON policy from a URL. The ETag of the last
+// policy is sent with every request, so the policy is only downloaded
+// and parsed again when it changed.
+type URLLoader struct {
+	url         string
+	bearerToken string
+	client      *http.Client
+
+	mu   sync.Mutex
+	etag string
+	data []byte
+	pol  *ACLPolicy
+}
+
+// NewURLLoader returns a URLLoader for the policy at url. If bearerToken
+// is not empty, it is sent in the Authorization header.
+func NewURLLoader(url string, bearerToken string) *URLLoader {
+	return &URLLoader{
+		url:         url,
+		bearerToken: bearerToken,
+		client: &http.Client{
+			Timeout: types.HTTPTimeout,
+		},
+	}
+}
+
+// Load fetches and parses the policy. If the server reports that the
+// policy has not been modified since the last successful Load, the
+// last policy is returned and changed is false. A fetched policy is only
+// remembered if it parses and validate, when not nil, accepts it.
+// Otherwise the last policy is kept, and the policy is fetched again on
+// the next Load.
+func (l *URLLoader) Load(ctx context.Context, validate func(*ACLPolicy) error) (*ACLPolicy, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if l.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+l.bearerToken)
+	}
+
+	if l.etag != "" && l.pol != nil {
+		req.Header.Set("If-None-Match", l.etag)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		if l.pol != nil {
+			return l.pol, false, nil
+		}
+
+		fallthrough
+	default:
+		return nil, false, fmt.Errorf("%w: %s", ErrPolicyFetch, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPolicySize+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("reading policy: %w", err)
+	}
+
+	if len(data) > maxPolicySize {
+		return nil, false, fmt.Errorf("%w, more than %d bytes", ErrPolicyTooLarge, maxPolicySize)
+	}
+
+	// Servers without ETag support send the same policy again.
+	if l.pol != nil && bytes.Equal(data, l.data) {
+		l.etag = resp.Header.Get("ETag")
+
+		return l.pol, false, nil
+	}
+
+	pol, err := LoadACLPolicyFromBytes(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if validate != nil {
+		if err := validate(pol); err != nil {
+			return nil, false, err
+		}
+	}
+
+	l.etag = resp.Header.Get("ETag")
+	l.data = data
+	l.pol = pol
+
+	return pol, true, nil
+}
+
+// Data returns the last policy accepted by Load, as it was received.
+func (l *URLLoader) Data() []byte {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.data
+}
@@ -0,0 +1,86 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLLoader(t *testing.T) {
+	policy := `{"acls": [{"action": "accept", "src": ["*"], "dst": ["*:*"]}]}`
+	etag := `"v1"`
+	requests := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(policy))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	_, _, err := NewURLLoader(srv.URL, "wrong").Load(ctx, nil)
+	require.ErrorIs(t, err, ErrPolicyFetch)
+
+	loader := NewURLLoader(srv.URL, "secret")
+
+	pol, changed, err := loader.Load(ctx, nil)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Len(t, pol.ACLs, 1)
+	assert.Equal(t, policy, string(loader.Data()))
+
+	again, changed, err := loader.Load(ctx, nil)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Same(t, pol, again)
+
+	// An invalid policy is rejected and the last one is kept.
+	policy = `{"acls": [}`
+	etag = `"v2"`
+	_, _, err = loader.Load(ctx, nil)
+	require.Error(t, err)
+	assert.Contains(t, string(loader.Data()), "accept")
+
+	// A policy rejected by the validation is not kept either, and it is
+	// fetched again.
+	policy = `{"groups": {"group:admins": ["user1"]}}`
+	etag = `"v3"`
+	errRejected := errors.New("rejected")
+	_, _, err = loader.Load(ctx, func(*ACLPolicy) error { return errRejected })
+	require.ErrorIs(t, err, errRejected)
+	assert.Contains(t, string(loader.Data()), "accept")
+
+	pol, changed, err = loader.Load(ctx, func(*ACLPolicy) error { return nil })
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Empty(t, pol.ACLs)
+	assert.Len(t, pol.Groups, 1)
+	assert.Equal(t, policy, string(loader.Data()))
+
+	policy = strings.Repeat(" ", maxPolicySize) + policy
+	etag = `"v4"`
+	_, _, err = loader.Load(ctx, nil)
+	require.ErrorIs(t, err, ErrPolicyTooLarge)
+	assert.Equal(t, 7, requests)
+}
@@ -82,7 +82,7 @@ func (h *Headscale) newMapSession(
 		w:      w,
 		node:   node,
 		capVer: req.Version,
-		mapper: h.mapper,
+		mapper: h.mapper.NewSession(h.DERPMap),
 
 		ch:           updateChan,
 		cancelCh:     make(chan struct{}),
@@ -285,8 +285,10 @@ func (m *mapSession) serveLongPoll() {
 			updateType := "full"
 			switch update.Type {
 			case types.StateFullUpdate:
+				// Only the first full update of the session sends
+				// the full map, the next ones send what changed.
 				m.tracef("Sending Full MapResponse")
-				data, err = m.mapper.FullMapResponse(m.req, m.node, m.h.ACLPolicy, fmt.Sprintf("from mapSession: %p, stream: %t", m, m.isStreaming()))
+				data, err = m.mapper.FullMapResponse(m.req, m.node, m.h.ACLPolicy(), fmt.Sprintf("from mapSession: %p, stream: %t", m, m.isStreaming()))
 			case types.StatePeerChanged:
 				changed := make(map[types.NodeID]bool, len(update.ChangeNodes))
 
@@ -296,12 +298,12 @@ func (m *mapSession) serveLongPoll() {
 
 				lastMessage = update.Message
 				m.tracef(fmt.Sprintf("Sending Changed MapResponse: %v", lastMessage))
-				data, err = m.mapper.PeerChangedResponse(m.req, m.node, changed, update.ChangePatches, m.h.ACLPolicy, lastMessage)
+				data, err = m.mapper.PeerChangedResponse(m.req, m.node, changed, update.ChangePatches, m.h.ACLPolicy(), lastMessage)
 				updateType = "change"
 
 			case types.StatePeerChangedPatch:
 				m.tracef(fmt.Sprintf("Sending Changed Patch MapResponse: %v", lastMessage))
-				data, err = m.mapper.PeerChangedPatchResponse(m.req, m.node, update.ChangePatches, m.h.ACLPolicy)
+				data, err = m.mapper.PeerChangedPatchResponse(m.req, m.node, update.ChangePatches, m.h.ACLPolicy())
 				updateType = "patch"
 			case types.StatePeerRemoved:
 				changed := make(map[types.NodeID]bool, len(update.Removed))
@@ -310,18 +312,22 @@ func (m *mapSession) serveLongPoll() {
 					changed[nodeID] = false
 				}
 				m.tracef(fmt.Sprintf("Sending Changed MapResponse: %v", lastMessage))
-				data, err = m.mapper.PeerChangedResponse(m.req, m.node, changed, update.ChangePatches, m.h.ACLPolicy, lastMessage)
+				data, err = m.mapper.PeerChangedResponse(m.req, m.node, changed, update.ChangePatches, m.h.ACLPolicy(), lastMessage)
 				updateType = "remove"
 			case types.StateSelfUpdate:
 				lastMessage = update.Message
 				m.tracef(fmt.Sprintf("Sending Changed MapResponse: %v", lastMessage))
 				// create the map so an empty (self) update is sent
-				data, err = m.mapper.PeerChangedResponse(m.req, m.node, make(map[types.NodeID]bool), update.ChangePatches, m.h.ACLPolicy, lastMessage)
+				data, err = m.mapper.PeerChangedResponse(m.req, m.node, make(map[types.NodeID]bool), update.ChangePatches, m.h.ACLPolicy(), lastMessage)
 				updateType = "remove"
 			case types.StateDERPUpdated:
 				m.tracef("Sending DERPUpdate MapResponse")
 				data, err = m.mapper.DERPMapResponse(m.req, m.node, m.h.DERPMap)
 				updateType = "derp"
+			case types.StatePingRequest:
+				m.tracef("Sending PingRequest MapResponse")
+				data, err = m.mapper.PingRequestResponse(m.req, m.node, update.PingRequest)
+				updateType = "ping"
 			}
 
 			if err != nil {
@@ -447,6 +453,7 @@ func (m *mapSession) handleEndpointUpdate() {
 	m.node.ApplyPeerChange(&change)
 
 	sendUpdate, routesChanged := hostInfoChanged(m.node.Hostinfo, m.req.Hostinfo)
+	policyChanged := routesChanged || hostinfoChangesPolicy(m.node.Hostinfo, m.req.Hostinfo)
 
 	// The node might not set NetInfo if it has not changed and if
 	// the full HostInfo object is overwritten, the information is lost.
@@ -488,15 +495,17 @@ func (m *mapSession) handleEndpointUpdate() {
 			return
 		}
 
-		if m.h.ACLPolicy != nil {
+		if m.h.ACLPolicy() != nil {
 			// update routes with peer information
-			err := m.h.db.EnableAutoApprovedRoutes(m.h.ACLPolicy, m.node)
+			err := m.h.db.EnableAutoApprovedRoutes(m.h.ACLPolicy(), m.node)
 			if err != nil {
 				m.errf(err, "Error running auto approved routes")
 				mapResponseEndpointUpdates.WithLabelValues("error").Inc()
 			}
 		}
 
+		m.h.nodeNotifier.NodesChanged()
+
 		// Send an update to the node itself with to ensure it
 		// has an updated packetfilter allowing the new route
 		// if it is defined in the ACL.
@@ -524,6 +533,12 @@ func (m *mapSession) handleEndpointUpdate() {
 		return
 	}
 
+	// Endpoint and DERP changes are frequent, the compiled policy is only
+	// dropped when the Hostinfo it depends on changed.
+	if policyChanged {
+		m.h.nodeNotifier.NodesChanged()
+	}
+
 	ctx := types.NotifyCtx(context.Background(), "poll-nodeupdate-peers-patch", m.node.Hostname)
 	m.h.nodeNotifier.NotifyWithIgnore(
 		ctx,
@@ -544,7 +559,7 @@ func (m *mapSession) handleEndpointUpdate() {
 func (m *mapSession) handleReadOnlyRequest() {
 	m.tracef("Client asked for a lite update, responding without peers")
 
-	mapResp, err := m.mapper.ReadOnlyMapResponse(m.req, m.node, m.h.ACLPolicy)
+	mapResp, err := m.mapper.ReadOnlyMapResponse(m.req, m.node, m.h.ACLPolicy())
 	if err != nil {
 		m.errf(err, "Failed to create MapResponse")
 		http.Error(m.w, "", http.StatusInternalServerError)
@@ -664,6 +679,18 @@ func logPollFunc(
 // - second reports if there has been changes to routes
 // the caller can then use this info to save and update nodes
 // and routes as needed.
+// hostinfoChangesPolicy reports if the Hostinfo used to compile the policy
+// changed: the requested tags, and the OS and version used by postures.
+func hostinfoChangesPolicy(old, new *tailcfg.Hostinfo) bool {
+	if old == nil || new == nil {
+		return old != new
+	}
+
+	return !slices.Equal(old.RequestTags, new.RequestTags) ||
+		old.OS != new.OS ||
+		old.IPNVersion != new.IPNVersion
+}
+
 func hostInfoChanged(old, new *tailcfg.Hostinfo) (bool, bool) {
 	if old.Equal(new) {
 		return false, false
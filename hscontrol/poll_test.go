@@ -0,0 +1,46 @@
+package hscontrol
+
+import (
+	"gopkg.in/check.v1"
+	"tailscale.com/tailcfg"
+)
+
+func (s *Suite) TestHostinfoChangesPolicy(c *check.C) {
+	old := &tailcfg.Hostinfo{
+		OS:          "linux",
+		IPNVersion:  "1.76.1",
+		RequestTags: []string{"tag:server"},
+	}
+
+	tests := []struct {
+		name string
+		old  *tailcfg.Hostinfo
+		new  *tailcfg.Hostinfo
+		want bool
+	}{
+		{"unchanged", old, old.Clone(), false},
+		{"netinfo", old, func() *tailcfg.Hostinfo {
+			hi := old.Clone()
+			hi.NetInfo = &tailcfg.NetInfo{PreferredDERP: 6}
+
+			return hi
+		}(), false},
+		{"request-tags", old, func() *tailcfg.Hostinfo {
+			hi := old.Clone()
+			hi.RequestTags = []string{"tag:web"}
+
+			return hi
+		}(), true},
+		{"version", old, func() *tailcfg.Hostinfo {
+			hi := old.Clone()
+			hi.IPNVersion = "1.78.0"
+
+			return hi
+		}(), true},
+		{"first-hostinfo", nil, old, true},
+	}
+
+	for _, tt := range tests {
+		c.Check(hostinfoChangesPolicy(tt.old, tt.new), check.Equals, tt.want, check.Commentf(tt.name))
+	}
+}
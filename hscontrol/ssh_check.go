@@ -0,0 +1,308 @@
+package hscontrol
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/juanfont/headscale/hscontrol/policy"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"github.com/juanfont/headscale/hscontrol/util"
+	"github.com/rs/zerolog/log"
+	"tailscale.com/tailcfg"
+	"zgo.at/zcache/v2"
+)
+
+const (
+	// sshCheckTimeout is how long a held SSH connection waits for the
+	// user to re-authenticate, Tailscale clients give up after 30 minutes.
+	sshCheckTimeout        = 30 * time.Minute
+	sshCheckCleanup        = 35 * time.Minute
+	sshCheckIDLength       = 32
+	sshCheckWaitPathPrefix = "https://unused/machine/ssh/wait/"
+)
+
+var (
+	ErrSSHCheckNotFound  = errors.New("SSH check not found or expired")
+	errSSHCheckWrongNode = errors.New("SSH check belongs to another node")
+)
+
+// sshCheck is an SSH connection held by a check action until the user of
+// the source node re-authenticates.
+type sshCheck struct {
+	ID        string
+	SrcNodeID types.NodeID
+	DstNodeID types.NodeID
+	SSHUser   string
+	LocalUser string
+	Period    time.Duration
+	Expires   time.Time
+
+	// Recorders and OnRecordingFailure are passed on to the accept
+	// action, which decides how the session is recorded.
+	Recorders          []netip.AddrPort
+	OnRecordingFailure *tailcfg.SSHRecorderFailureAction
+
+	approved chan struct{}
+	once     sync.Once
+}
+
+// sshChecks tracks the pending SSH checks, and when the user of a node
+// last approved one.
+type sshChecks struct {
+	pending *zcache.Cache[string, *sshCheck]
+
+	mu       sync.Mutex
+	approved map[types.NodeID]time.Time
+}
+
+func newSSHChecks() *sshChecks {
+	return &sshChecks{
+		pending:  zcache.New[string, *sshCheck](sshCheckTimeout, sshCheckCleanup),
+		approved: make(map[types.NodeID]time.Time),
+	}
+}
+
+// approvedWithin reports whether the user of the node has approved an SSH
+// check within the period. A zero period is never approved in advance.
+func (c *sshChecks) approvedWithin(nodeID types.NodeID, period time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	approved, ok := c.approved[nodeID]
+
+	return ok && period > 0 && time.Since(approved) < period
+}
+
+func (c *sshChecks) start(check *sshCheck) (*sshCheck, error) {
+	randomBlob := make([]byte, randomByteSize)
+	if _, err := rand.Read(randomBlob); err != nil {
+		return nil, err
+	}
+
+	check.ID = hex.EncodeToString(randomBlob)[:sshCheckIDLength]
+	check.Expires = time.Now().Add(sshCheckTimeout)
+	check.approved = make(chan struct{})
+	c.pending.Set(check.ID, check)
+
+	return check, nil
+}
+
+func (c *sshChecks) get(id string) (*sshCheck, error) {
+	check, ok := c.pending.Get(id)
+	if !ok {
+		return nil, ErrSSHCheckNotFound
+	}
+
+	return check, nil
+}
+
+// approve releases the connection held by the check, and remembers the
+// approval for the check period. The check is kept until it expires, so
+// the destination node sees the approval if it reconnects.
+func (c *sshChecks) approve(id string) (*sshCheck, error) {
+	check, ok := c.pending.Get(id)
+	if !ok {
+		return nil, ErrSSHCheckNotFound
+	}
+
+	c.mu.Lock()
+	c.approved[check.SrcNodeID] = time.Now()
+	c.mu.Unlock()
+
+	check.once.Do(func() {
+		close(check.approved)
+	})
+
+	return check, nil
+}
+
+// wait blocks until the check is approved, expires, or ctx is done.
+func (check *sshCheck) wait(ctx context.Context) (bool, error) {
+	timer := time.NewTimer(time.Until(check.Expires))
+	defer timer.Stop()
+
+	select {
+	case <-check.approved:
+		return true, nil
+	case <-timer.C:
+		return false, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+func sshCheckURL(serverURL string, id string) string {
+	return fmt.Sprintf(
+		"%s/ssh/check/%s",
+		strings.TrimSuffix(serverURL, "/"),
+		id)
+}
+
+func sshCheckAccept(
+	recorders []netip.AddrPort,
+	onRecordingFailure *tailcfg.SSHRecorderFailureAction,
+) tailcfg.SSHAction {
+	return tailcfg.SSHAction{
+		Accept:                   true,
+		AllowAgentForwarding:     true,
+		AllowLocalPortForwarding: true,
+		Recorders:                recorders,
+		OnRecordingFailure:       onRecordingFailure,
+	}
+}
+
+func sshCheckReject(message string) tailcfg.SSHAction {
+	return tailcfg.SSHAction{
+		Reject:  true,
+		Message: message + "\n",
+	}
+}
+
+// SSHActionHandler decides on an SSH connection held by a check action
+// of the SSH policy, see policy.SSHCheckURL. It is requested by the
+// destination node of the connection. The connection is accepted if the
+// user of the source node has approved a check within the check period,
+// otherwise the user is asked to re-authenticate and the connection is
+// held until SSHWaitHandler accepts it.
+// Listens in /machine/ssh/action/from/{src_node_id}/to/{dst_node_id}.
+func (ns *noiseServer) SSHActionHandler(
+	writer http.ResponseWriter,
+	req *http.Request,
+) {
+	vars := mux.Vars(req)
+	srcNodeID, err := strconv.ParseUint(vars["src_node_id"], util.Base10, 64)
+	if err != nil {
+		http.Error(writer, "invalid source node ID", http.StatusBadRequest)
+
+		return
+	}
+
+	dstNodeID, err := strconv.ParseUint(vars["dst_node_id"], util.Base10, 64)
+	if err != nil {
+		http.Error(writer, "invalid destination node ID", http.StatusBadRequest)
+
+		return
+	}
+
+	period, err := time.ParseDuration(req.URL.Query().Get("check_period"))
+	if err != nil {
+		http.Error(writer, "invalid check period", http.StatusBadRequest)
+
+		return
+	}
+
+	recorders, onRecordingFailure, err := policy.ParseSSHCheckRecorders(req.URL.Query())
+	if err != nil {
+		http.Error(writer, "invalid recorders", http.StatusBadRequest)
+
+		return
+	}
+
+	dst, err := ns.headscale.db.GetNodeByMachineKey(ns.machineKey)
+	if err != nil || dst.ID != types.NodeID(dstNodeID) {
+		http.Error(writer, errSSHCheckWrongNode.Error(), http.StatusForbidden)
+
+		return
+	}
+
+	src, err := ns.headscale.db.GetNodeByID(types.NodeID(srcNodeID))
+	if err != nil {
+		writeSSHAction(writer, sshCheckReject("# Unknown source node."))
+
+		return
+	}
+
+	checks := ns.headscale.sshChecks
+	if checks.approvedWithin(src.ID, period) {
+		writeSSHAction(writer, sshCheckAccept(recorders, onRecordingFailure))
+
+		return
+	}
+
+	check, err := checks.start(&sshCheck{
+		SrcNodeID: src.ID,
+		DstNodeID: dst.ID,
+		SSHUser:   req.URL.Query().Get("ssh_user"),
+		LocalUser: req.URL.Query().Get("local_user"),
+		Period:    period,
+
+		Recorders:          recorders,
+		OnRecordingFailure: onRecordingFailure,
+	})
+	if err != nil {
+		http.Error(writer, "Internal server error", http.StatusInternalServerError)
+
+		return
+	}
+
+	log.Info().
+		Str("check", check.ID).
+		Str("src", src.Hostname).
+		Str("dst", dst.Hostname).
+		Str("ssh_user", check.SSHUser).
+		Msg("Holding SSH connection until the user re-authenticates")
+
+	writeSSHAction(writer, tailcfg.SSHAction{
+		Message: fmt.Sprintf(
+			"# Headscale SSH requires an additional check.\n# To authenticate, visit: %s\n",
+			ns.headscale.authProvider.SSHCheckURL(check.ID),
+		),
+		HoldAndDelegate: sshCheckWaitPathPrefix + check.ID,
+	})
+}
+
+// SSHWaitHandler holds an SSH connection until the check is approved.
+// Listens in /machine/ssh/wait/{check_id}.
+func (ns *noiseServer) SSHWaitHandler(
+	writer http.ResponseWriter,
+	req *http.Request,
+) {
+	check, err := ns.headscale.sshChecks.get(mux.Vars(req)["check_id"])
+	if err != nil {
+		writeSSHAction(writer, sshCheckReject("# The SSH check has expired."))
+
+		return
+	}
+
+	dst, err := ns.headscale.db.GetNodeByMachineKey(ns.machineKey)
+	if err != nil || dst.ID != check.DstNodeID {
+		http.Error(writer, errSSHCheckWrongNode.Error(), http.StatusForbidden)
+
+		return
+	}
+
+	approved, err := check.wait(req.Context())
+	if err != nil {
+		// The client fetches the URL again as long as the
+		// connection is open.
+		return
+	}
+
+	if !approved {
+		writeSSHAction(writer, sshCheckReject("# The SSH check was not approved in time."))
+
+		return
+	}
+
+	writeSSHAction(writer, sshCheckAccept(check.Recorders, check.OnRecordingFailure))
+}
+
+func writeSSHAction(writer http.ResponseWriter, action tailcfg.SSHAction) {
+	writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+	writer.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(writer).Encode(action); err != nil {
+		util.LogErr(err, "Failed to write SSH action")
+	}
+}
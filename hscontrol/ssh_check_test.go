@@ -0,0 +1,136 @@
+package hscontrol
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/juanfont/headscale/hscontrol/policy"
+	"github.com/juanfont/headscale/hscontrol/types"
+	"gopkg.in/check.v1"
+	"tailscale.com/tailcfg"
+	"tailscale.com/types/key"
+)
+
+func (s *Suite) TestSSHCheck(c *check.C) {
+	user, err := app.db.CreateUser("test")
+	c.Assert(err, check.IsNil)
+
+	srcKey := key.NewMachine().Public()
+	dstKey := key.NewMachine().Public()
+	src := types.Node{Hostname: "laptop", MachineKey: srcKey, UserID: user.ID}
+	dst := types.Node{Hostname: "server", MachineKey: dstKey, UserID: user.ID}
+	c.Assert(app.db.DB.Save(&src).Error, check.IsNil)
+	c.Assert(app.db.DB.Save(&dst).Error, check.IsNil)
+
+	ns := &noiseServer{headscale: app, machineKey: dstKey}
+
+	// fetch requests the URL of a check action as the destination node
+	// expands it, and decodes the SSH action.
+	fetch := func(ns *noiseServer, url string) (int, tailcfg.SSHAction) {
+		url = strings.NewReplacer(
+			"https://unused", "",
+			"$SRC_NODE_ID", src.ID.String(),
+			"$DST_NODE_ID", dst.ID.String(),
+			"$SSH_USER", "root",
+			"$LOCAL_USER", "root",
+		).Replace(url)
+
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		rec := httptest.NewRecorder()
+
+		router := mux.NewRouter()
+		router.HandleFunc("/machine/ssh/action/from/{src_node_id}/to/{dst_node_id}", ns.SSHActionHandler)
+		router.HandleFunc("/machine/ssh/wait/{check_id}", ns.SSHWaitHandler)
+		router.ServeHTTP(rec, req)
+
+		var action tailcfg.SSHAction
+		if rec.Code == http.StatusOK {
+			c.Assert(json.Unmarshal(rec.Body.Bytes(), &action), check.IsNil)
+		}
+
+		return rec.Code, action
+	}
+
+	checkURL := policy.SSHCheckURL(time.Hour)
+
+	// Another node cannot decide on the connection.
+	code, _ := fetch(&noiseServer{headscale: app, machineKey: srcKey}, checkURL)
+	c.Assert(code, check.Equals, http.StatusForbidden)
+
+	// The connection is held until the user re-authenticates.
+	code, action := fetch(ns, checkURL)
+	c.Assert(code, check.Equals, http.StatusOK)
+	c.Assert(action.Accept, check.Equals, false)
+	c.Assert(action.Reject, check.Equals, false)
+	c.Assert(action.HoldAndDelegate, check.Matches, sshCheckWaitPathPrefix+"[0-9a-f]{32}")
+
+	checkID := strings.TrimPrefix(action.HoldAndDelegate, sshCheckWaitPathPrefix)
+	c.Assert(action.Message, check.Matches, "(?s).*/ssh/check/"+checkID+"\n")
+
+	waited := make(chan tailcfg.SSHAction)
+	go func() {
+		_, action := fetch(ns, action.HoldAndDelegate)
+		waited <- action
+	}()
+
+	_, err = app.sshChecks.approve(checkID)
+	c.Assert(err, check.IsNil)
+
+	select {
+	case action = <-waited:
+		c.Assert(action.Accept, check.Equals, true)
+	case <-time.After(5 * time.Second):
+		c.Fatal("SSH check was not accepted after approval")
+	}
+
+	// The approval is remembered for the check period.
+	_, action = fetch(ns, checkURL)
+	c.Assert(action.Accept, check.Equals, true)
+
+	// A check that is always required holds the connection again.
+	_, action = fetch(ns, policy.SSHCheckURL(0))
+	c.Assert(action.Accept, check.Equals, false)
+	c.Assert(action.HoldAndDelegate, check.Not(check.Equals), "")
+
+	// The accept of a check records the session like the rule asks.
+	recordedURL := policy.SSHCheckURL(0) + "&enforce_recorder=true&recorder=100.64.0.20%3A80"
+	wantRecorders := []netip.AddrPort{netip.MustParseAddrPort("100.64.0.20:80")}
+
+	_, action = fetch(ns, recordedURL)
+	c.Assert(action.HoldAndDelegate, check.Not(check.Equals), "")
+
+	go func() {
+		_, action := fetch(ns, action.HoldAndDelegate)
+		waited <- action
+	}()
+
+	_, err = app.sshChecks.approve(strings.TrimPrefix(action.HoldAndDelegate, sshCheckWaitPathPrefix))
+	c.Assert(err, check.IsNil)
+
+	select {
+	case action = <-waited:
+		c.Assert(action.Accept, check.Equals, true)
+		c.Assert(action.Recorders, check.DeepEquals, wantRecorders)
+		c.Assert(action.OnRecordingFailure, check.NotNil)
+	case <-time.After(5 * time.Second):
+		c.Fatal("SSH check was not accepted after approval")
+	}
+
+	_, action = fetch(ns, strings.Replace(recordedURL, "check_period=0s", "check_period=1h0m0s", 1))
+	c.Assert(action.Accept, check.Equals, true)
+	c.Assert(action.Recorders, check.DeepEquals, wantRecorders)
+	c.Assert(action.OnRecordingFailure, check.NotNil)
+
+	// An enforced recorder without recorders is refused.
+	code, _ = fetch(ns, policy.SSHCheckURL(time.Hour)+"&enforce_recorder=true")
+	c.Assert(code, check.Equals, http.StatusBadRequest)
+
+	// Unknown checks are rejected.
+	_, action = fetch(ns, sshCheckWaitPathPrefix+"unknown")
+	c.Assert(action.Reject, check.Equals, true)
+}
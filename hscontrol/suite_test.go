@@ -3,6 +3,7 @@ package hscontrol
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/juanfont/headscale/hscontrol/types"
 	"gopkg.in/check.v1"
@@ -47,6 +48,10 @@ func (s *Suite) ResetDB(c *check.C) {
 			},
 		},
 		OIDC: types.OIDCConfig{},
+		Tuning: types.Tuning{
+			NotifierSendTimeout: time.Second,
+			BatchChangeDelay:    time.Second,
+		},
 	}
 
 	app, err = NewHeadscale(&cfg)
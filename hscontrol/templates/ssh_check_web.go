@@ -0,0 +1,27 @@
+package templates
+
+import (
+	"fmt"
+
+	"github.com/chasefleming/elem-go"
+	"github.com/chasefleming/elem-go/attrs"
+	"github.com/chasefleming/elem-go/styles"
+)
+
+func SSHCheckWeb(checkID string) *elem.Element {
+	return HtmlStructure(
+		elem.Title(nil, elem.Text("SSH check - Headscale")),
+		elem.Body(attrs.Props{
+			attrs.Style: styles.Props{
+				styles.FontFamily: "sans",
+			}.ToInline(),
+		},
+			elem.H1(nil, elem.Text("headscale")),
+			elem.H2(nil, elem.Text("SSH check")),
+			elem.P(nil, elem.Text("Run the command below in the headscale server to approve this SSH connection: ")),
+			elem.Code(attrs.Props{attrs.Style: codeStyleRegisterWebAPI.ToInline()},
+				elem.Text(fmt.Sprintf("headscale nodes approve-ssh --check %s", checkID)),
+			),
+		),
+	)
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"errors"
+	"time"
+
+	v1 "github.com/juanfont/headscale/gen/go/headscale/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var ErrAccessGrantNotFound = errors.New("access grant not found")
+
+// AccessGrant is a temporary rule added to the policy, allowing the
+// sources to reach the destinations on the ports until it expires.
+// Sources and destinations are written like in the acls of the policy.
+type AccessGrant struct {
+	ID           uint64   `gorm:"primary_key"`
+	Sources      []string `gorm:"serializer:json"`
+	Destinations []string `gorm:"serializer:json"`
+
+	// Ports are written like in the acls of the policy, e.g. "22",
+	// "80,443" or "*".
+	Ports    string
+	Protocol string
+
+	Reason string
+
+	// Author is the prefix of the API key that created the grant, or
+	// "cli-socket" if it was created over the local socket.
+	Author string
+
+	CreatedAt  time.Time
+	Expiration time.Time `gorm:"index"`
+}
+
+func (grant *AccessGrant) Proto() *v1.AccessGrant {
+	return &v1.AccessGrant{
+		Id:           grant.ID,
+		Sources:      grant.Sources,
+		Destinations: grant.Destinations,
+		Ports:        grant.Ports,
+		Protocol:     grant.Protocol,
+		Reason:       grant.Reason,
+		Author:       grant.Author,
+		CreatedAt:    timestamppb.New(grant.CreatedAt),
+		Expiration:   timestamppb.New(grant.Expiration),
+	}
+}
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrBroadcastMessageNotFound = errors.New("no message has been broadcast")
+
+// BroadcastMessage is a message from an admin shown in the health UI of
+// every client. Every change is stored as a new row, the last one is
+// shown, and an empty Message clears it.
+type BroadcastMessage struct {
+	ID      uint64 `gorm:"primary_key"`
+	Message string
+
+	// Author is the prefix of the API key that sent the message, or
+	// "cli-socket" if it was sent over the local socket.
+	Author    string
+	CreatedAt time.Time
+}
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrClientVersionNotFound = errors.New("latest client version not set")
+
+// ClientVersion is the latest stable Tailscale version, as set by an
+// admin. Every change is stored as a new row, the last one is used, and
+// an empty Version falls back to the configured source.
+type ClientVersion struct {
+	ID      uint64 `gorm:"primary_key"`
+	Version string
+
+	// Author is the prefix of the API key that set the version, or
+	// "cli-socket" if it was set over the local socket.
+	Author    string
+	CreatedAt time.Time
+}
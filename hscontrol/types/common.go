@@ -33,6 +33,8 @@ func (su StateUpdateType) String() string {
 		return "StateSelfUpdate"
 	case StateDERPUpdated:
 		return "StateDERPUpdated"
+	case StatePingRequest:
+		return "StatePingRequest"
 	}
 
 	return "unknown state update type"
@@ -54,6 +56,9 @@ const (
 	// which should have a length of one.
 	StateSelfUpdate
 	StateDERPUpdated
+	// StatePingRequest asks the node to answer the
+	// PingRequest.
+	StatePingRequest
 )
 
 // StateUpdate is an internal message containing information about
@@ -81,6 +86,9 @@ type StateUpdate struct {
 	// contain the new DERP Map.
 	DERPMap *tailcfg.DERPMap
 
+	// PingRequest must be set when Type is StatePingRequest.
+	PingRequest *tailcfg.PingRequest
+
 	// Additional message for tracking origin or what being
 	// updated, useful for ambiguous updates like StatePeerChanged.
 	Message string
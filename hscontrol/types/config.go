@@ -44,6 +44,7 @@ type PolicyMode string
 const (
 	PolicyModeDB   = "database"
 	PolicyModeFile = "file"
+	PolicyModeURL  = "url"
 )
 
 // Config contains the initial Headscale configuration.
@@ -85,6 +86,10 @@ type Config struct {
 
 	Policy PolicyConfig
 
+	ClientVersion ClientVersionConfig
+
+	Health HealthConfig
+
 	Tuning Tuning
 }
 
@@ -197,6 +202,29 @@ type CLIConfig struct {
 type PolicyConfig struct {
 	Path string
 	Mode PolicyMode
+
+	URL             string
+	BearerToken     string
+	RefreshInterval time.Duration
+}
+
+// ClientVersionConfig is where the latest stable Tailscale version is read
+// from, and how clients running an older version are notified.
+type ClientVersionConfig struct {
+	Path            string
+	URL             string
+	RefreshInterval time.Duration
+
+	Notify    bool
+	NotifyURL string
+}
+
+// HealthConfig is what the nodes are warned about in the health UI of
+// their client.
+type HealthConfig struct {
+	// KeyExpiryWarning is how long before its key expires a node is
+	// warned, zero disables the warning.
+	KeyExpiryWarning time.Duration
 }
 
 type LogConfig struct {
@@ -237,6 +265,12 @@ func LoadConfig(path string, isFile bool) error {
 	viper.AutomaticEnv()
 
 	viper.SetDefault("policy.mode", "file")
+	viper.SetDefault("policy.refresh_interval", "5m")
+
+	viper.SetDefault("client_version.refresh_interval", "6h")
+	viper.SetDefault("client_version.notify", true)
+
+	viper.SetDefault("health.key_expiry_warning", "0s")
 
 	viper.SetDefault("tls_letsencrypt_cache_dir", "/var/www/.cache")
 	viper.SetDefault("tls_letsencrypt_challenge_type", HTTP01ChallengeType)
@@ -363,6 +397,17 @@ func validateServerConfig() error {
 		errorText += "Fatal config error: server_url must start with https:// or http://\n"
 	}
 
+	if viper.GetString("policy.mode") == PolicyModeURL {
+		if !strings.HasPrefix(viper.GetString("policy.url"), "http://") &&
+			!strings.HasPrefix(viper.GetString("policy.url"), "https://") {
+			errorText += "Fatal config error: policy.url must start with https:// or http:// when policy.mode is url\n"
+		}
+
+		if viper.GetDuration("policy.refresh_interval") <= 0 {
+			errorText += "Fatal config error: policy.refresh_interval must be positive when policy.mode is url\n"
+		}
+	}
+
 	// Minimum inactivity time out is keepalive timeout (60s) plus a few seconds
 	// to avoid races
 	minInactivityTimeout, _ := time.ParseDuration("65s")
@@ -477,6 +522,21 @@ func policyConfig() PolicyConfig {
 	return PolicyConfig{
 		Path: policyPath,
 		Mode: PolicyMode(policyMode),
+
+		URL:             viper.GetString("policy.url"),
+		BearerToken:     viper.GetString("policy.bearer_token"),
+		RefreshInterval: viper.GetDuration("policy.refresh_interval"),
+	}
+}
+
+func clientVersionConfig() ClientVersionConfig {
+	return ClientVersionConfig{
+		Path:            util.AbsolutePathFromConfigPath(viper.GetString("client_version.path")),
+		URL:             viper.GetString("client_version.url"),
+		RefreshInterval: viper.GetDuration("client_version.refresh_interval"),
+
+		Notify:    viper.GetBool("client_version.notify"),
+		NotifyURL: viper.GetString("client_version.notify_url"),
 	}
 }
 
@@ -904,6 +964,12 @@ func LoadServerConfig() (*Config, error) {
 
 		Policy: policyConfig(),
 
+		ClientVersion: clientVersionConfig(),
+
+		Health: HealthConfig{
+			KeyExpiryWarning: viper.GetDuration("health.key_expiry_warning"),
+		},
+
 		CLI: CLIConfig{
 			Address:  viper.GetString("cli.address"),
 			APIKey:   viper.GetString("cli.api_key"),
@@ -180,6 +180,19 @@ func TestReadConfig(t *testing.T) {
 				"policy.path": "/etc/policy.hujson",
 			},
 		},
+		{
+			name:       "key-expiry-warning-disabled-by-default",
+			configPath: "testdata/base-domain-not-in-server-url.yaml",
+			setup: func(t *testing.T) (any, error) {
+				cfg, err := LoadServerConfig()
+				if err != nil {
+					return nil, err
+				}
+
+				return cfg.Health, nil
+			},
+			want: HealthConfig{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	v1 "github.com/juanfont/headscale/gen/go/headscale/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"tailscale.com/tailcfg"
+	"tailscale.com/util/dnsname"
+)
+
+var (
+	ErrDNSRecordNotFound = errors.New("DNS record not found")
+	ErrDNSRecordExists   = errors.New("DNS record already exists")
+	ErrInvalidDNSRecord  = errors.New("invalid DNS record")
+)
+
+// DNSRecord is a DNS record sent to the nodes along with the
+// extra_records of the configuration. Unlike those, it is managed
+// through the API, and may expire.
+type DNSRecord struct {
+	ID    uint64 `gorm:"primary_key"`
+	Name  string `gorm:"index"`
+	Type  string
+	Value string
+
+	// Author is the prefix of the API key that created the record, or
+	// "cli-socket" if it was created over the local socket.
+	Author string
+
+	CreatedAt time.Time
+	// Expiration is nil if the record does not expire.
+	Expiration *time.Time `gorm:"index"`
+}
+
+// Normalize validates the record, and writes its name in lower case
+// without the trailing dot and its type in upper case. An empty type is
+// A or AAAA, depending on the value. Other types are rejected, Tailscale
+// clients only resolve A and AAAA records for now.
+func (record *DNSRecord) Normalize() error {
+	fqdn, err := dnsname.ToFQDN(strings.ToLower(record.Name))
+	if err != nil {
+		return fmt.Errorf("%w: name %q: %s", ErrInvalidDNSRecord, record.Name, err)
+	}
+	record.Name = fqdn.WithoutTrailingDot()
+	record.Type = strings.ToUpper(record.Type)
+
+	switch record.Type {
+	case "", "A", "AAAA":
+		addr, err := netip.ParseAddr(record.Value)
+		if err != nil {
+			return fmt.Errorf("%w: value %q is not an IP address", ErrInvalidDNSRecord, record.Value)
+		}
+
+		want := "AAAA"
+		if addr.Is4() {
+			want = "A"
+		}
+		if record.Type != "" && record.Type != want {
+			return fmt.Errorf("%w: value %q of a %s record", ErrInvalidDNSRecord, record.Value, record.Type)
+		}
+		record.Type = want
+		record.Value = addr.String()
+
+	default:
+		return fmt.Errorf("%w: unsupported type %q", ErrInvalidDNSRecord, record.Type)
+	}
+
+	return nil
+}
+
+func (record *DNSRecord) Proto() *v1.DNSRecord {
+	protoRecord := &v1.DNSRecord{
+		Id:        record.ID,
+		Name:      record.Name,
+		Type:      record.Type,
+		Value:     record.Value,
+		Author:    record.Author,
+		CreatedAt: timestamppb.New(record.CreatedAt),
+	}
+
+	if record.Expiration != nil {
+		protoRecord.Expiration = timestamppb.New(*record.Expiration)
+	}
+
+	return protoRecord
+}
+
+func (record *DNSRecord) TailscaleDNSRecord() tailcfg.DNSRecord {
+	return tailcfg.DNSRecord{
+		Name:  record.Name,
+		Type:  record.Type,
+		Value: record.Value,
+	}
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDNSRecordNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  DNSRecord
+		want    DNSRecord
+		wantErr bool
+	}{
+		{
+			name:   "a-inferred",
+			record: DNSRecord{Name: "CI-42.Example.com.", Value: "100.64.0.7"},
+			want:   DNSRecord{Name: "ci-42.example.com", Type: "A", Value: "100.64.0.7"},
+		},
+		{
+			name:   "aaaa-inferred",
+			record: DNSRecord{Name: "ci-42.example.com", Value: "fd7a:115c:a1e0:0::7"},
+			want:   DNSRecord{Name: "ci-42.example.com", Type: "AAAA", Value: "fd7a:115c:a1e0::7"},
+		},
+		{
+			name:    "a-with-ipv6",
+			record:  DNSRecord{Name: "ci.example.com", Type: "A", Value: "fd7a:115c:a1e0::7"},
+			wantErr: true,
+		},
+		{
+			name:    "a-not-ip",
+			record:  DNSRecord{Name: "ci.example.com", Type: "A", Value: "ci"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid-name",
+			record:  DNSRecord{Name: "ci..example.com", Value: "100.64.0.7"},
+			wantErr: true,
+		},
+		{
+			name:    "cname",
+			record:  DNSRecord{Name: "www.example.com", Type: "cname", Value: "web.example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "txt",
+			record:  DNSRecord{Name: "_ci.example.com", Type: "TXT", Value: "job=42"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported-type",
+			record:  DNSRecord{Name: "ci.example.com", Type: "MX", Value: "mail.example.com"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := tt.record
+
+			err := record.Normalize()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDNSRecord) {
+					t.Fatalf("Normalize() error = %v, want %v", err, ErrInvalidDNSRecord)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("Normalize() unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, record); diff != "" {
+				t.Errorf("Normalize() unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -11,10 +11,22 @@ var (
 	ErrPolicyUpdateIsDisabled = errors.New("update is disabled for modes other than 'database'")
 )
 
-// Policy represents a policy in the database.
+// PolicyAuthorSocket is the author of policies set over the local socket,
+// which does not require an API key.
+const PolicyAuthorSocket = "cli-socket"
+
+// Policy represents a policy in the database. Every accepted policy is
+// stored as a new revision, identified by its ID, and never changed.
 type Policy struct {
 	gorm.Model
 
 	// Data contains the policy in HuJSON format.
 	Data string
+
+	// Author is the prefix of the API key that set the policy, or
+	// "cli-socket" if it was set over the local socket.
+	Author string
+
+	// Message is an optional description of the change.
+	Message string
 }
@@ -2,6 +2,7 @@ package types
 
 import (
 	"cmp"
+	"slices"
 	"strconv"
 
 	v1 "github.com/juanfont/headscale/gen/go/headscale/v1"
@@ -41,6 +42,10 @@ type User struct {
 	Provider string
 
 	ProfilePicURL string
+
+	// Groups the user is a member of at the OIDC provider,
+	// from the groups claim, refreshed at every login.
+	Groups []string `gorm:"serializer:json"`
 }
 
 // Username is the main way to get the username of a user,
@@ -134,5 +139,6 @@ func (u *User) FromClaim(claims *OIDCClaims) {
 	u.Email = claims.Email
 	u.Name = claims.Username
 	u.ProfilePicURL = claims.ProfilePictureURL
+	u.Groups = slices.Compact(slices.Sorted(slices.Values(claims.Groups)))
 	u.Provider = util.RegisterMethodOIDC
 }
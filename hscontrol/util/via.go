@@ -0,0 +1,104 @@
+package util
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"tailscale.com/net/tsaddr"
+)
+
+// MaxViaSiteID is the largest site ID Tailscale clients accept in a 4via6
+// route.
+const MaxViaSiteID = 65535
+
+// viaBits is the length of the part of a 4via6 prefix in front of the
+// IPv4 prefix, the via range and the site ID.
+const viaBits = 96
+
+var ErrInvalidViaPrefix = errors.New("invalid 4via6 prefix")
+
+// IsViaPrefix reports whether prefix is in the Tailscale 4via6 range,
+// fd7a:115c:a1e0:b1a::/64.
+func IsViaPrefix(prefix netip.Prefix) bool {
+	return tsaddr.IsViaPrefix(prefix)
+}
+
+// ValidateViaPrefix checks that a prefix in the 4via6 range is a masked
+// prefix containing a site ID and an IPv4 prefix.
+func ValidateViaPrefix(prefix netip.Prefix) error {
+	if prefix != prefix.Masked() {
+		return fmt.Errorf("%w: %s contains non-network bits set", ErrInvalidViaPrefix, prefix)
+	}
+
+	if prefix.Bits() < viaBits {
+		return fmt.Errorf("%w: %s must be at least a /%d", ErrInvalidViaPrefix, prefix, viaBits)
+	}
+
+	if siteID, _ := UnmapViaPrefix(prefix); siteID > MaxViaSiteID {
+		return fmt.Errorf("%w: site ID %d of %s is larger than %d", ErrInvalidViaPrefix, siteID, prefix, MaxViaSiteID)
+	}
+
+	return nil
+}
+
+// ViaPrefix returns the 4via6 prefix of an IPv4 prefix at the site.
+func ViaPrefix(siteID uint32, v4 netip.Prefix) (netip.Prefix, error) {
+	if siteID > MaxViaSiteID {
+		return netip.Prefix{}, fmt.Errorf("%w: site ID %d is larger than %d", ErrInvalidViaPrefix, siteID, MaxViaSiteID)
+	}
+
+	via, err := tsaddr.MapVia(siteID, v4.Masked())
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%w: %w", ErrInvalidViaPrefix, err)
+	}
+
+	return via, nil
+}
+
+// UnmapViaPrefix returns the site ID and the IPv4 prefix of a 4via6
+// prefix of at least /96.
+func UnmapViaPrefix(prefix netip.Prefix) (uint32, netip.Prefix) {
+	addr := prefix.Addr().As16()
+	siteID := binary.BigEndian.Uint32(addr[8:12])
+
+	return siteID, netip.PrefixFrom(tsaddr.UnmapVia(prefix.Addr()), prefix.Bits()-viaBits)
+}
+
+// ParseViaAlias parses an alias of the form "via:<site-id>:<ipv4>" where
+// ipv4 is an IPv4 address or prefix, and returns the 4via6 prefix it
+// refers to.
+func ParseViaAlias(alias string) (netip.Prefix, error) {
+	site, v4Str, ok := strings.Cut(strings.TrimPrefix(alias, "via:"), ":")
+	if !ok {
+		return netip.Prefix{}, fmt.Errorf("%w: %q, expected via:<site-id>:<ipv4 prefix>", ErrInvalidViaPrefix, alias)
+	}
+
+	siteID, err := strconv.ParseUint(site, Base10, 32)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%w: site ID of %q: %w", ErrInvalidViaPrefix, alias, err)
+	}
+
+	var v4 netip.Prefix
+	if strings.Contains(v4Str, "/") {
+		v4, err = netip.ParsePrefix(v4Str)
+	} else {
+		var addr netip.Addr
+		addr, err = netip.ParseAddr(v4Str)
+		v4 = netip.PrefixFrom(addr, addr.BitLen())
+	}
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%w: %q: %w", ErrInvalidViaPrefix, alias, err)
+	}
+
+	return ViaPrefix(uint32(siteID), v4)
+}
+
+// ViaDNSName returns the name Tailscale clients resolve to the 4via6
+// address of the IPv4 address at the site, like "192-168-1-10-via-7".
+func ViaDNSName(siteID uint32, v4 netip.Addr) string {
+	return strings.ReplaceAll(v4.String(), ".", "-") + "-via-" + strconv.FormatUint(uint64(siteID), Base10)
+}
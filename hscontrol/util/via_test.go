@@ -0,0 +1,90 @@
+package util
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestParseViaAlias(t *testing.T) {
+	tests := []struct {
+		alias   string
+		want    netip.Prefix
+		wantErr error
+	}{
+		{
+			alias: "via:7:192.168.1.0/24",
+			want:  netip.MustParsePrefix("fd7a:115c:a1e0:b1a:0:7:c0a8:100/120"),
+		},
+		{
+			alias: "via:7:192.168.1.10",
+			want:  netip.MustParsePrefix("fd7a:115c:a1e0:b1a:0:7:c0a8:10a/128"),
+		},
+		{
+			alias: "via:65535:10.0.0.0/8",
+			want:  netip.MustParsePrefix("fd7a:115c:a1e0:b1a:0:ffff:a00:0/104"),
+		},
+		{
+			alias:   "via:65536:10.0.0.0/8",
+			wantErr: ErrInvalidViaPrefix,
+		},
+		{
+			alias:   "via:site:10.0.0.0/8",
+			wantErr: ErrInvalidViaPrefix,
+		},
+		{
+			alias:   "via:7:fd00::/64",
+			wantErr: ErrInvalidViaPrefix,
+		},
+		{
+			alias:   "via:7",
+			wantErr: ErrInvalidViaPrefix,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			got, err := ParseViaAlias(tt.alias)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseViaAlias() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseViaAlias() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateViaPrefix(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		wantErr bool
+	}{
+		{prefix: "fd7a:115c:a1e0:b1a:0:7:c0a8:100/120"},
+		{prefix: "fd7a:115c:a1e0:b1a:0:7::/96"},
+		{prefix: "fd7a:115c:a1e0:b1a::/64", wantErr: true},
+		{prefix: "fd7a:115c:a1e0:b1a:0:7:c0a8:101/120", wantErr: true},
+		{prefix: "fd7a:115c:a1e0:b1a:1:0:c0a8:100/120", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			err := ValidateViaPrefix(netip.MustParsePrefix(tt.prefix))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateViaPrefix() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnmapViaPrefix(t *testing.T) {
+	siteID, v4 := UnmapViaPrefix(netip.MustParsePrefix("fd7a:115c:a1e0:b1a:0:7:c0a8:100/120"))
+	if siteID != 7 || v4 != netip.MustParsePrefix("192.168.1.0/24") {
+		t.Errorf("UnmapViaPrefix() = %d, %s, want 7, 192.168.1.0/24", siteID, v4)
+	}
+
+	if got := ViaDNSName(siteID, netip.MustParseAddr("192.168.1.10")); got != "192-168-1-10-via-7" {
+		t.Errorf("ViaDNSName() = %s, want 192-168-1-10-via-7", got)
+	}
+}
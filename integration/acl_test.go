@@ -521,7 +521,7 @@ func TestACLNamedHostsCanReachBySubnet(t *testing.T) {
 	scenario := aclScenario(t,
 		&policy.ACLPolicy{
 			Hosts: policy.Hosts{
-				"all": netip.MustParsePrefix("100.64.0.0/24"),
+				"all": {"100.64.0.0/24"},
 			},
 			ACLs: []policy.ACL{
 				// Everyone can curl test3
@@ -621,9 +621,9 @@ func TestACLNamedHostsCanReach(t *testing.T) {
 		"ipv4": {
 			policy: policy.ACLPolicy{
 				Hosts: policy.Hosts{
-					"test1": netip.MustParsePrefix("100.64.0.1/32"),
-					"test2": netip.MustParsePrefix("100.64.0.2/32"),
-					"test3": netip.MustParsePrefix("100.64.0.3/32"),
+					"test1": {"100.64.0.1/32"},
+					"test2": {"100.64.0.2/32"},
+					"test3": {"100.64.0.3/32"},
 				},
 				ACLs: []policy.ACL{
 					// Everyone can curl test3
@@ -644,9 +644,9 @@ func TestACLNamedHostsCanReach(t *testing.T) {
 		"ipv6": {
 			policy: policy.ACLPolicy{
 				Hosts: policy.Hosts{
-					"test1": netip.MustParsePrefix("fd7a:115c:a1e0::1/128"),
-					"test2": netip.MustParsePrefix("fd7a:115c:a1e0::2/128"),
-					"test3": netip.MustParsePrefix("fd7a:115c:a1e0::3/128"),
+					"test1": {"fd7a:115c:a1e0::1/128"},
+					"test2": {"fd7a:115c:a1e0::2/128"},
+					"test3": {"fd7a:115c:a1e0::3/128"},
 				},
 				ACLs: []policy.ACL{
 					// Everyone can curl test3
@@ -892,8 +892,8 @@ func TestACLDevice1CanAccessDevice2(t *testing.T) {
 		"hostv4cidr": {
 			policy: policy.ACLPolicy{
 				Hosts: policy.Hosts{
-					"test1": netip.MustParsePrefix("100.64.0.1/32"),
-					"test2": netip.MustParsePrefix("100.64.0.2/32"),
+					"test1": {"100.64.0.1/32"},
+					"test2": {"100.64.0.2/32"},
 				},
 				ACLs: []policy.ACL{
 					{
@@ -907,8 +907,8 @@ func TestACLDevice1CanAccessDevice2(t *testing.T) {
 		"hostv6cidr": {
 			policy: policy.ACLPolicy{
 				Hosts: policy.Hosts{
-					"test1": netip.MustParsePrefix("fd7a:115c:a1e0::1/128"),
-					"test2": netip.MustParsePrefix("fd7a:115c:a1e0::2/128"),
+					"test1": {"fd7a:115c:a1e0::1/128"},
+					"test2": {"fd7a:115c:a1e0::2/128"},
 				},
 				ACLs: []policy.ACL{
 					{